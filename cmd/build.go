@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gitutil"
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var buildCmdExample = strings.Trim(`
+  godev build
+  godev build ./cmd/server
+  godev build --platforms linux/amd64,linux/arm64,darwin/arm64,windows/amd64
+  godev build --output bin --cgo --trimpath=false
+  godev build --version v1.2.3 --version-pkg github.com/acme/app/internal/version
+`, strconst.NewLine)
+
+var (
+	buildPlatformsFlag  []string
+	buildOutputFlag     string
+	buildTrimPathFlag   bool
+	buildCGOFlag        bool
+	buildLDFlagsFlag    string
+	buildVersionFlag    string
+	buildVersionPkgFlag string
+)
+
+var buildCmd = &cobra.Command{
+	Use:     "build [packages...]",
+	Short:   "Build the main packages for one or more platforms",
+	Example: buildCmdExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		applyBuildConfig(cmd, cfg, cfg.Build.Platforms)
+
+		opts, err := buildOptionsFromFlags(args)
+		if err != nil {
+			return err
+		}
+
+		if err := osutil.RemoveDirIfExist(opts.OutputDir); err != nil {
+			return fmt.Errorf("failed to remove output directory: %w", err)
+		}
+
+		fmt.Printf("%s Building %s (%s) for %d platform(s)...\n", strconst.EmojiRocket, strings.Join(opts.Packages, ", "), opts.Version, len(opts.Platforms))
+		artifacts, err := gobuild.Build(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		printArtifacts(artifacts)
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Built %d artifact(s) into %s", strconst.EmojiSuccess, len(artifacts), opts.OutputDir)))
+		return nil
+	},
+}
+
+// applyBuildConfig fills the build flags not set on the command line from godev.yaml
+func applyBuildConfig(cmd *cobra.Command, cfg *config.Config, platforms []string) {
+	flags := cmd.Flags()
+	if !flags.Changed("platforms") && len(platforms) > 0 {
+		buildPlatformsFlag = platforms
+	}
+	if !flags.Changed("output") {
+		buildOutputFlag = cfg.Build.Output
+	}
+	if !flags.Changed("trimpath") {
+		buildTrimPathFlag = cfg.Build.TrimPath
+	}
+	if !flags.Changed("cgo") {
+		buildCGOFlag = cfg.Build.CGO
+	}
+	if !flags.Changed("ldflags") {
+		buildLDFlagsFlag = cfg.Build.LDFlags
+	}
+	if !flags.Changed("version-pkg") {
+		buildVersionPkgFlag = cfg.Build.VersionPkg
+	}
+}
+
+// buildOptionsFromFlags resolves the build flags, main packages and version metadata into build options
+func buildOptionsFromFlags(packages []string) (gobuild.Options, error) {
+	platforms, err := gobuild.ParsePlatforms(buildPlatformsFlag)
+	if err != nil {
+		return gobuild.Options{}, err
+	}
+
+	if len(packages) == 0 {
+		if packages, err = gobuild.MainPackages(); err != nil {
+			return gobuild.Options{}, fmt.Errorf("failed to list main packages: %w", err)
+		}
+		if len(packages) == 0 {
+			return gobuild.Options{}, errors.New("no main package found in the current module")
+		}
+	}
+
+	version := buildVersionFlag
+	if version == strconst.Empty {
+		if version, err = gitutil.Describe(); err != nil {
+			fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Failed to describe the version from git, using 'dev': %s", strconst.EmojiWarning, err.Error())))
+			version = "dev"
+		}
+	}
+
+	commit, err := gitutil.ShortCommit()
+	if err != nil {
+		commit = "unknown"
+	}
+
+	return gobuild.Options{
+		Packages:   packages,
+		Platforms:  platforms,
+		OutputDir:  buildOutputFlag,
+		TrimPath:   buildTrimPathFlag,
+		CGO:        buildCGOFlag,
+		LDFlags:    buildLDFlagsFlag,
+		VersionPkg: buildVersionPkgFlag,
+		Version:    version,
+		Commit:     commit,
+		Date:       time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func printArtifacts(artifacts []gobuild.Artifact) {
+	rows := make([][]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		size := tui.FormatSize(a.Size)
+		if osutil.DryRun() {
+			size = "-"
+		}
+		rows = append(rows, []string{a.Platform.String(), a.Package, a.Path, size})
+	}
+	fmt.Println(tui.RenderTable([]string{"Platform", "Package", "Artifact", "Size"}, rows))
+}
+
+// addBuildFlags registers the flags shared by the build and release commands
+func addBuildFlags(cmd *cobra.Command, defaultPlatforms []string) {
+	cmd.Flags().StringSliceVar(&buildPlatformsFlag, "platforms", defaultPlatforms, "Target platforms as GOOS/GOARCH, comma separated")
+	cmd.Flags().StringVarP(&buildOutputFlag, "output", "o", "dist", "Output directory for the build artifacts")
+	cmd.Flags().BoolVar(&buildTrimPathFlag, "trimpath", true, "Remove file system paths from the binaries")
+	cmd.Flags().BoolVar(&buildCGOFlag, "cgo", false, "Enable cgo (CGO_ENABLED=1)")
+	cmd.Flags().StringVar(&buildLDFlagsFlag, "ldflags", strconst.Empty, "Extra flags passed to the linker")
+	cmd.Flags().StringVar(&buildVersionFlag, "version", strconst.Empty, "Version to inject, defaults to 'git describe --tags --always --dirty'")
+	cmd.Flags().StringVar(&buildVersionPkgFlag, "version-pkg", "main", "Package holding the version, commit and date variables to inject")
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+	addBuildFlags(buildCmd, []string{runtime.GOOS + "/" + runtime.GOARCH})
+}
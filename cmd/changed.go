@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/gitutil"
+	"github.com/thought2code/godev/internal/osutil"
+)
+
+// defaultChangedBase is the git revision --changed compares against when --base is not set
+const defaultChangedBase = "origin/main"
+
+// changes are the files changed since the merge base of HEAD and a base revision
+type changes struct {
+	rev string
+	// goFiles are the changed Go files which still exist
+	goFiles []string
+	// files are the absolute paths of all changed files, deleted ones included
+	files []string
+}
+
+func changedSince(base string) (*changes, error) {
+	rev, err := gitutil.MergeBase(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the merge base with %s: %w", base, err)
+	}
+	files, err := gitutil.ChangedFiles(rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the files changed since %s: %w", base, err)
+	}
+
+	c := &changes{rev: rev}
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		c.files = append(c.files, abs)
+		if exist, err := osutil.CheckExist(file); err == nil && exist && strings.HasSuffix(file, ".go") {
+			c.goFiles = append(c.goFiles, file)
+		}
+	}
+	return c, nil
+}
+
+func addChangedFlags(cmd *cobra.Command, changed *bool, base *string) {
+	cmd.Flags().BoolVar(changed, "changed", false, "Only handle the Go files changed since the merge base with --base")
+	cmd.Flags().StringVar(base, "base", defaultChangedBase, "Git revision the changes are computed against")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var configCmdExample = strings.Trim(`
+  godev config show
+  godev config validate
+  godev config validate path/to/godev.yaml
+`, strconst.NewLine)
+
+var configCmd = &cobra.Command{
+	Use:     "config",
+	Short:   "Inspect the project configuration (godev.yaml)",
+	Example: configCmdExample,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to get help: %s", strconst.EmojiFailure, err.Error())))
+			return
+		}
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:     "show",
+	Short:   "Print the effective configuration merged with the defaults",
+	Example: "  godev config show",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+
+		data, err := config.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal configuration: %w", err)
+		}
+
+		if cfg.Path == strconst.Empty {
+			fmt.Printf("# no %s found, showing the defaults\n", config.FileName)
+		} else {
+			fmt.Printf("# %s merged with the defaults\n", cfg.Path)
+		}
+		fmt.Print(string(data))
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:     "validate [file]",
+	Short:   "Validate the configuration file and report schema errors",
+	Example: "  godev config validate",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		path := strconst.Empty
+		if len(args) > 0 {
+			path = args[0]
+		} else {
+			found, err := config.Find(CurrentDir)
+			if err != nil {
+				return fmt.Errorf("failed to find %s: %w", config.FileName, err)
+			}
+			if found == strconst.Empty {
+				fmt.Println(tui.WarnStyle(fmt.Sprintf("%s No %s found up to the module root, the defaults are used", strconst.EmojiWarning, config.FileName)))
+				return nil
+			}
+			path = found
+		}
+
+		if _, err := config.LoadFile(path); err != nil {
+			return configError(err)
+		}
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s is valid", strconst.EmojiSuccess, path)))
+		return nil
+	},
+}
+
+// loadProjectConfig loads godev.yaml merged with the defaults, the error listing the issues
+// when it is invalid
+func loadProjectConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, configError(err)
+	}
+	return cfg, nil
+}
+
+// configError describes an error of config.Load, with one line per issue of an invalid file
+func configError(err error) error {
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return fmt.Errorf("invalid configuration %s:\n%w", validationErr.Path, err)
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
@@ -1,174 +1,259 @@
 package cmd
 
 import (
-	"debug/buildinfo"
 	"fmt"
 	"os"
-	"os/exec"
-	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/mod/modfile"
 
-	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/doctor"
+	"github.com/thought2code/godev/internal/gotool"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
 
+var doctorCmdExample = strings.Trim(`
+  godev doctor
+  godev doctor --list
+  godev doctor --only "Go version"
+  godev doctor --skip "Go tools goimports,Go tools gofumpt"
+  godev doctor --format json
+  godev doctor --format sarif --strict > doctor.sarif
+  godev doctor --fix
+  godev doctor --fix --yes
+`, strconst.NewLine)
+
+// exit codes of 'godev doctor'
+const (
+	doctorExitFailure = 1
+	doctorExitWarning = 2
+)
+
+var (
+	doctorListFlag   bool
+	doctorOnlyFlag   []string
+	doctorSkipFlag   []string
+	doctorFormatFlag string
+	doctorStrictFlag bool
+	doctorFixFlag    bool
+	doctorYesFlag    bool
+)
+
 var doctorCmd = &cobra.Command{
 	Use:     "doctor",
 	Short:   "Diagnose the health of the development environment",
-	Example: "  godev doctor",
-	Run: func(cmd *cobra.Command, args []string) {
-		runDoctor()
+	Example: doctorCmdExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if doctorFormatFlag != "text" && doctorFormatFlag != "json" && doctorFormatFlag != "sarif" {
+			return fmt.Errorf("invalid format %q, expected text, json or sarif", doctorFormatFlag)
+		}
+
+		if doctorFixFlag && doctorFormatFlag != "text" {
+			return fmt.Errorf("--fix is only supported with the text format")
+		}
+
+		if doctorListFlag {
+			listDoctorChecks()
+			return nil
+		}
+
+		report, err := runDoctorChecks(doctorOnlyFlag, doctorSkipFlag, doctorFormatFlag == "text")
+		if err != nil {
+			return err
+		}
+
+		if doctorFixFlag && applyDoctorFixes(report) {
+			fmt.Println("🔁 Checking again after the fixes...")
+			before := report
+			if report, err = runDoctorChecks(doctorOnlyFlag, doctorSkipFlag, false); err != nil {
+				return err
+			}
+			printDoctorComparison(before, report)
+		}
+
+		switch doctorFormatFlag {
+		case "json":
+			err = report.WriteJSON(os.Stdout)
+		case "sarif":
+			err = report.WriteSARIF(os.Stdout, rootCmd.Version)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write the %s report: %w", doctorFormatFlag, err)
+		}
+
+		if report.Failures > 0 {
+			return &ExitError{Code: doctorExitFailure, Err: fmt.Errorf("doctor found %d failed check(s) and %d warning(s)", report.Failures, report.Warnings)}
+		}
+		if report.Warnings > 0 && doctorStrictFlag {
+			return &ExitError{Code: doctorExitWarning, Err: fmt.Errorf("doctor found %d warning(s) in strict mode", report.Warnings)}
+		}
+		return nil
 	},
 }
 
-func runDoctor() {
-	fmt.Println("🔍 Diagnosing the development environment...")
-
-	type check struct {
-		name   string
-		result *checkResult
-	}
-
-	checks := []check{
-		{
-			name:   "Go module file",
-			result: checkGoModuleFile(),
-		},
-		{
-			name:   "Go version",
-			result: checkGoVersion(),
-		},
-		{
-			name:   "Go tools gofumpt",
-			result: checkGoToolsVersion("gofumpt"),
-		},
-		{
-			name:   "Go tools goimports",
-			result: checkGoToolsVersion("goimports"),
-		},
-		{
-			name:   "Go tools golangci-lint-v2",
-			result: checkGoToolsVersion("golangci-lint-v2"),
-		},
+// newDoctorRegistry registers the built-in checks followed by the ones declared in godev.yaml
+func newDoctorRegistry() (*doctor.Registry, *config.Config, error) {
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = config.Default()
+	}
+	// an invalid go.mod is reported by the Go version check
+	if tools, err := gotool.ProjectTools(cfg); err == nil {
+		cfg.Tools = tools
 	}
 
-	for _, c := range checks {
-		if c.result.passed {
-			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiSuccess, c.name, c.result.message)))
-		} else {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiFailure, c.name, c.result.message)))
-			fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Remedy: %s", strconst.EmojiTips, c.result.advice)))
-		}
+	registry := doctor.NewRegistry()
+	if err := registry.Register(doctor.Builtin(cfg, cfgErr, installProjectTool)...); err != nil {
+		return nil, nil, err
+	}
+	if err := registry.Register(doctor.FromConfig(cfg.Doctor.Checks)...); err != nil {
+		return nil, nil, err
 	}
+	return registry, cfg, nil
 }
 
-type checkResult struct {
-	passed  bool
-	message string
-	advice  string
+// runDoctor prints the diagnosis of the development environment, used before other commands
+func runDoctor(only, skip []string) {
+	if _, err := runDoctorChecks(only, skip, true); err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+	}
 }
 
-func checkGoModuleFile() *checkResult {
-	exist, err := osutil.CheckExist("go.mod")
+func runDoctorChecks(only, skip []string, printText bool) (*doctor.Report, error) {
+	if printText {
+		fmt.Println("🔍 Diagnosing the development environment...")
+	}
+
+	registry, cfg, err := newDoctorRegistry()
 	if err != nil {
-		return &checkResult{
-			passed:  false,
-			message: err.Error(),
-			advice:  "Check if the go.mod file exists and readable",
-		}
+		return nil, err
 	}
-	if !exist {
-		return &checkResult{
-			passed:  false,
-			message: "The go.mod file does not exist",
-			advice:  "Create the go.mod file using 'go mod init'",
-		}
+
+	checks, err := registry.Filter(only, append(cfg.Doctor.Skip, skip...))
+	if err != nil {
+		return nil, err
 	}
 
-	return &checkResult{
-		passed:  true,
-		message: "The go.mod file exists",
-		advice:  strconst.Empty,
+	var onResult func(doctor.CheckReport)
+	if printText {
+		onResult = printDoctorResult
 	}
+	return doctor.Run(checks, onResult), nil
 }
 
-func checkGoVersion() *checkResult {
-	checkGoModuleFileResult := checkGoModuleFile()
-	if !checkGoModuleFileResult.passed {
-		return &checkResult{
-			passed:  false,
-			message: "No go.mod file found, unable to check required Go version",
-			advice:  "Create the go.mod file using 'go mod init'",
-		}
+func printDoctorResult(r doctor.CheckReport) {
+	switch {
+	case r.Passed:
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiSuccess, r.Name, r.Message)))
+	case r.Severity == doctor.SeverityWarning:
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiWarning, r.Name, r.Message)))
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Remedy: %s", strconst.EmojiTips, r.Advice)))
+	default:
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiFailure, r.Name, r.Message)))
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Remedy: %s", strconst.EmojiTips, r.Advice)))
 	}
+}
 
-	data, err := os.ReadFile("go.mod")
-	if err != nil {
-		return &checkResult{
-			passed:  false,
-			message: err.Error(),
-			advice:  "Check if the go.mod file exists and readable",
+// applyDoctorFixes applies the fixes of the failed checks after confirmation, reporting whether any was applied
+func applyDoctorFixes(report *doctor.Report) (applied bool) {
+	fixable := 0
+	for _, r := range report.Checks {
+		fix := r.Fix()
+		if fix == nil {
+			continue
 		}
-	}
+		fixable++
 
-	mod, err := modfile.Parse("go.mod", data, nil)
-	if err != nil {
-		return &checkResult{
-			passed:  false,
-			message: err.Error(),
-			advice:  "Check if the go.mod file is a valid Go module file",
+		if !doctorYesFlag {
+			fmt.Print(tui.WarnStyle(fmt.Sprintf("%s Fix '%s': %s? (Y/n): ", strconst.EmojiQuestion, r.Name, fix.Description)))
+			if confirm, err := tui.ReadUserInput(); err != nil {
+				fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to read input: %s", strconst.EmojiFailure, err.Error())))
+				return applied
+			} else if confirm != "Y" && confirm != "y" {
+				fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Skipped fixing '%s'", strconst.EmojiWarning, r.Name)))
+				continue
+			}
 		}
-	}
 
-	if mod.Go == nil {
-		return &checkResult{
-			passed:  false,
-			message: "The Go version directive is missing in the go.mod file",
-			advice:  "Add the Go version directive to the go.mod file",
+		fmt.Printf("🔧 Fixing '%s': %s\n", r.Name, fix.Description)
+		if err := fix.Apply(); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to fix '%s': %s", strconst.EmojiFailure, r.Name, err.Error())))
+			continue
 		}
+		applied = true
 	}
 
-	requiredGoVersion := "go" + mod.Go.Version
-	installedGoVersion := runtime.Version()
-	passed := installedGoVersion >= requiredGoVersion
-	advice := strconst.Empty
-	if !passed {
-		advice = fmt.Sprintf("Upgrade Go to version %s or higher, download from https://golang.org/dl/", requiredGoVersion)
+	if fixable == 0 {
+		fmt.Println(tui.WarnStyle(strconst.EmojiTips + " No automatic fix available, follow the remedies above"))
 	}
+	return applied
+}
+
+// missingCheckStatus is shown in the comparison for a check that only ran before or after the fixes
+const missingCheckStatus = "—"
 
-	return &checkResult{
-		passed:  passed,
-		message: fmt.Sprintf("installed: %s, required: %s", installedGoVersion, requiredGoVersion),
-		advice:  advice,
+func printDoctorComparison(before, after *doctor.Report) {
+	status := func(r doctor.CheckReport) string {
+		switch {
+		case r.Passed:
+			return strconst.EmojiSuccess + " passed"
+		case r.Severity == doctor.SeverityWarning:
+			return strconst.EmojiWarning + " warning"
+		default:
+			return strconst.EmojiFailure + " failed"
+		}
 	}
-}
 
-func checkGoToolsVersion(toolName string) *checkResult {
-	path, err := exec.LookPath(toolName)
-	if err != nil {
-		return &checkResult{
-			passed:  false,
-			message: "Not installed",
-			advice:  fmt.Sprintf("Run 'godev tools install' to install %s version: %s", toolName, strconst.RecommendedGoimportsVersion),
+	// the checks are matched by name, a fix can change which checks run, like one installing a tool
+	beforeChecks := make(map[string]doctor.CheckReport, len(before.Checks))
+	for _, r := range before.Checks {
+		beforeChecks[r.Name] = r
+	}
+
+	rows := make([][]string, 0, len(after.Checks))
+	seen := make(map[string]bool, len(after.Checks))
+	for _, r := range after.Checks {
+		seen[r.Name] = true
+		beforeStatus := missingCheckStatus
+		if b, ok := beforeChecks[r.Name]; ok {
+			beforeStatus = status(b)
+		}
+		rows = append(rows, []string{r.Name, beforeStatus, status(r), r.Message})
+	}
+	for _, r := range before.Checks {
+		if !seen[r.Name] {
+			rows = append(rows, []string{r.Name, status(r), missingCheckStatus, r.Message})
 		}
 	}
+	fmt.Println(tui.RenderTable([]string{"Check", "Before", "After", "Message"}, rows))
+}
 
-	version := "unknown"
-	info, err := buildinfo.ReadFile(path)
-	if err == nil {
-		version = info.Main.Version + " built with " + info.GoVersion
+func listDoctorChecks() {
+	registry, _, err := newDoctorRegistry()
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+		return
 	}
 
-	return &checkResult{
-		passed:  true,
-		message: fmt.Sprintf("installed version: %s", version),
-		advice:  strconst.Empty,
+	checks := registry.Checks()
+	rows := make([][]string, 0, len(checks))
+	for _, c := range checks {
+		rows = append(rows, []string{c.Name(), doctor.SourceOf(c), c.Description()})
 	}
+	fmt.Println(tui.RenderTable([]string{"Check", "Source", "Description"}, rows))
 }
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorListFlag, "list", false, "List all registered checks without running them")
+	doctorCmd.Flags().StringSliceVar(&doctorOnlyFlag, "only", nil, "Run only the named checks, comma separated")
+	doctorCmd.Flags().StringSliceVar(&doctorSkipFlag, "skip", nil, "Skip the named checks, comma separated (added to doctor.skip of godev.yaml)")
+	doctorCmd.Flags().StringVar(&doctorFormatFlag, "format", "text", "Output format: text, json or sarif")
+	doctorCmd.Flags().BoolVar(&doctorStrictFlag, "strict", false, "Exit with code 2 when there are warnings but no failures")
+	doctorCmd.Flags().BoolVar(&doctorFixFlag, "fix", false, "Apply the automatic fixes of the failed checks, then check again")
+	doctorCmd.Flags().BoolVarP(&doctorYesFlag, "yes", "y", false, "Apply the fixes without asking for confirmation")
 }
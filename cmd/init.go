@@ -1,17 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/gitutil"
+	"github.com/thought2code/godev/internal/lint"
 	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/scaffold"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
@@ -22,33 +28,113 @@ var TemplateFS embed.FS
 var initCmdExample = strings.Trim(`
   godev init
   godev init myproject
+  godev init myproject --lint-profile strict
+  godev init myproject --license MIT
+  godev init myservice --type http
+  godev init --list-types
+  godev init myservice --template ../service-template
+  godev init myservice --template https://github.com/acme/go-templates//service@v1.2.0
+  godev init myservice --set module_path=github.com/acme/myservice
+  godev init myservice --template ../service-template --values values.yaml --set port=9090
 `, strconst.NewLine)
 
 const CurrentDir = "."
 
+var (
+	initLintProfileFlag string
+	initLicenseFlag     string
+	initTypeFlag        string
+	initListTypesFlag   bool
+	initTemplateFlag    string
+	initSetFlag         []string
+	initValuesFlag      string
+)
+
 var initCmd = &cobra.Command{
 	Use:     "init [project-name]",
 	Short:   "Initialize a new Go project from template",
 	Example: initCmdExample,
 	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if initListTypesFlag {
+			printProjectTypes()
+			return
+		}
+
 		projectName := CurrentDir
 		if len(args) > 0 {
 			projectName = args[0]
 		}
 		absPath, _ := filepath.Abs(projectName)
 
+		projectType, err := scaffold.FindType(initTypeFlag)
+		if err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+			return
+		}
+		profile, err := lint.FindProfile(initLintProfileFlag)
+		if err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+			return
+		}
+
+		variables := scaffold.BuiltinVariables()
+		var external *externalTemplate
+		if initTemplateFlag != strconst.Empty {
+			if cmd.Flags().Changed("type") {
+				fmt.Println(tui.ErrorStyle(strconst.EmojiFailure + " --type and --template can not be used together"))
+				return
+			}
+			if external = loadExternalTemplate(cmd.Context(), initTemplateFlag); external == nil {
+				return
+			}
+			variables = append(variables, external.manifest.Variables...)
+		}
+
+		values, ok := initValues(variables)
+		if !ok {
+			return
+		}
+
 		if !initInDir(absPath) {
 			return
 		}
 
-		gitRepo := userInputGitRepo()
-		if gitRepo == strconst.Empty {
-			gitRepo = filepath.Base(absPath)
+		data, ok := projectData(absPath, projectType, profile)
+		if !ok {
+			return
+		}
+		if external != nil {
+			// the project type and its features belong to the templates of godev
+			data.Type, data.Features = strconst.Empty, nil
 		}
+		vars, err := scaffold.ResolveVariables(variables, data, values, userInputVariable)
+		if err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+			return
+		}
+		data.ModulePath = strings.TrimPrefix(vars[scaffold.VarModulePath].(string), "https://")
+		data.Vars = vars
 
-		fmt.Printf("%s Creating Go project to: %s\n", strconst.EmojiRocket, absPath)
-		if !unpackTemplatesAndReplacePlaceholders(absPath, gitRepo) {
+		if external != nil {
+			fmt.Printf("%s Creating Go project from template %s to: %s\n", strconst.EmojiRocket, external.manifest.Name, absPath)
+			if !unpackExternalTemplate(cmd.Context(), absPath, external, data) {
+				return
+			}
+		} else {
+			fmt.Printf("%s Creating %s Go project to: %s\n", strconst.EmojiRocket, projectType.Name, absPath)
+			files, err := scaffold.RenderDirs(TemplateFS, projectType.Dirs("template"), data)
+			if err != nil {
+				fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to render template: %s", strconst.EmojiFailure, err.Error())))
+				return
+			}
+			if !writeProjectFiles(absPath, files) {
+				return
+			}
+			tidyProject(cmd.Context(), absPath)
+		}
+		if osutil.DryRun() {
+			fmt.Printf("%s Dry run, nothing was written to: %s\n", strconst.EmojiTips, absPath)
 			return
 		}
 		fmt.Printf("%s Project initialized successfully: %s\n", strconst.EmojiSuccess, absPath)
@@ -85,7 +171,7 @@ func initInDir(dirAbsPath string) (kontinue bool) {
 		}
 		return true
 	} else {
-		if err := os.MkdirAll(dirAbsPath, 0o755); err != nil {
+		if err := osutil.MkdirAll(dirAbsPath, 0o755); err != nil {
 			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to create project directory: %s", strconst.EmojiFailure, err.Error())))
 			return false
 		}
@@ -93,65 +179,139 @@ func initInDir(dirAbsPath string) (kontinue bool) {
 	}
 }
 
-func userInputGitRepo() string {
-	fmt.Printf("%s Git repository (optional, e.g. github.com/thought2code/godev, press Enter to skip): ", strconst.EmojiQuestion)
-	if input, err := tui.ReadUserInput(); err != nil {
-		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to read input: %s", strconst.EmojiFailure, err.Error())))
-		return strconst.Empty
-	} else {
-		return input
+// initValues reads the values of the variables given with --values and --set, the latter
+// taking precedence
+func initValues(variables []scaffold.Variable) (map[string]string, bool) {
+	values := map[string]string{}
+	if initValuesFlag != strconst.Empty {
+		data, err := os.ReadFile(initValuesFlag)
+		if err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to read values: %s", strconst.EmojiFailure, err.Error())))
+			return nil, false
+		}
+		if values, err = scaffold.ParseValues(data); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Invalid values file %s: %s", strconst.EmojiFailure, initValuesFlag, err.Error())))
+			return nil, false
+		}
+	}
+
+	set, err := scaffold.ParseSet(initSetFlag)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+		return nil, false
 	}
+	maps.Copy(values, set)
+
+	if err := scaffold.CheckValues(values, variables); err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+		return nil, false
+	}
+	return values, true
 }
 
-func unpackTemplatesAndReplacePlaceholders(dirAbsPath, gitRepo string) (success bool) {
-	files := map[string]string{
-		"template/.vscode/extensions.json.tpl": ".vscode/extensions.json",
-		"template/.vscode/launch.json.tpl":     ".vscode/launch.json",
-		"template/.vscode/settings.json.tpl":   ".vscode/settings.json",
-		"template/.gitignore.tpl":              ".gitignore",
-		"template/.golangci.yml.tpl":           ".golangci.yml",
-		"template/go.mod.tpl":                  "go.mod",
+// userInputVariable asks the value of a variable which was not given with --set or --values
+func userInputVariable(v scaffold.Variable, def string, invalid error) (string, error) {
+	if invalid != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, invalid.Error())))
+	} else if v.Help != strconst.Empty {
+		fmt.Printf("%s %s\n", strconst.EmojiTips, v.Help)
 	}
 
-	replacements := map[string]string{
-		"{{.ProjectName}}":     filepath.Base(dirAbsPath),
-		"{{.LatestGoVersion}}": fetchLatestGoVersion(),
-		"{{.GitRepo}}":         strings.TrimPrefix(gitRepo, "https://"),
+	prompt := v.Prompt
+	if prompt == strconst.Empty {
+		prompt = v.Name
+	}
+	switch v.Type {
+	case scaffold.VarBool:
+		prompt += " (yes/no)"
+	case scaffold.VarChoice:
+		prompt += fmt.Sprintf(" (%s)", strings.Join(v.Choices, "/"))
+	}
+	if def != strconst.Empty {
+		prompt += fmt.Sprintf(" [press Enter for %s]", def)
 	}
+	fmt.Printf("%s %s: ", strconst.EmojiQuestion, prompt)
 
-	for src, dest := range files {
-		if err := unpack(src, filepath.Join(dirAbsPath, dest), replacements); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to unpack template file %s: %s", strconst.EmojiFailure, src, err.Error())))
-			return false
-		}
-		fmt.Printf("%s Created file: %s\n", strconst.EmojiSuccess, filepath.Join(dirAbsPath, dest))
+	input, err := tui.ReadUserInput()
+	if err != nil {
+		return strconst.Empty, fmt.Errorf("failed to read input: %w", err)
 	}
-	return true
+	return strings.TrimSpace(input), nil
 }
 
-func unpack(src, dest string, replacements map[string]string) error {
-	bytes, err := TemplateFS.ReadFile(src)
+// projectData returns what the templates are rendered with
+func projectData(dirAbsPath string, projectType scaffold.ProjectType, profile lint.Profile) (data scaffold.Data, ok bool) {
+	linters, err := profile.RenderLinters()
 	if err != nil {
-		return fmt.Errorf("failed to read template file %s: %w", src, err)
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to render lint profile %s: %s", strconst.EmojiFailure, profile.Name, err.Error())))
+		return data, false
 	}
 
-	// ensure the directory exists
-	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
-		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+	return scaffold.Data{
+		ProjectName:        filepath.Base(dirAbsPath),
+		GoVersion:          fetchLatestGoVersion(),
+		Type:               projectType.Name,
+		Author:             gitutil.UserName(),
+		Year:               time.Now().Year(),
+		License:            initLicenseFlag,
+		Features:           projectType.Features,
+		LintProfileMarker:  profile.Marker(),
+		LintProfileLinters: strings.TrimSuffix(linters, strconst.NewLine),
+	}, true
+}
+
+func writeProjectFiles(dirAbsPath string, files []scaffold.File) (success bool) {
+	for _, file := range files {
+		dest, err := file.Dest(dirAbsPath)
+		if err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Refused to unpack template file: %s", strconst.EmojiFailure, err.Error())))
+			return false
+		}
+		if err := writeProjectFile(dest, file.Content); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to unpack template file %s: %s", strconst.EmojiFailure, file.Path, err.Error())))
+			return false
+		}
+		if !osutil.DryRun() {
+			fmt.Printf("%s Created file: %s\n", strconst.EmojiSuccess, dest)
+		}
 	}
+	return true
+}
 
-	// apply replacements
-	for key, value := range replacements {
-		bytes = []byte(strings.ReplaceAll(string(bytes), key, value))
+func writeProjectFile(dest string, content []byte) error {
+	// ensure the directory exists
+	if err := osutil.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
 	}
 
-	if err := os.WriteFile(dest, bytes, 0o644); err != nil {
+	if err := osutil.WriteFile(dest, content, 0o644); err != nil {
 		return fmt.Errorf("failed to write file %s: %w", dest, err)
 	}
 
 	return nil
 }
 
+// tidyProject adds the dependencies imported by the skeleton to go.mod and creates go.sum
+func tidyProject(ctx context.Context, dirAbsPath string) {
+	c := osutil.Command{Name: "go", Args: []string{"mod", "tidy"}, Dir: dirAbsPath}
+	if err := osutil.RunCommandContext(ctx, c); err != nil {
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Failed to tidy go.mod: %s", strconst.EmojiWarning, err.Error())))
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Run 'go mod tidy' in %s once the dependencies can be downloaded", strconst.EmojiTips, dirAbsPath)))
+	}
+}
+
+func printProjectTypes() {
+	rows := make([][]string, 0, len(scaffold.Types()))
+	for _, t := range scaffold.Types() {
+		name := t.Name
+		if name == scaffold.DefaultType {
+			name += " (default)"
+		}
+		rows = append(rows, []string{name, t.Description, strings.Join(t.Features, ", ")})
+	}
+	fmt.Println(tui.RenderTable([]string{"Type", "Description", "Features"}, rows))
+}
+
 func fetchLatestGoVersion() string {
 	// try to fetch from go.dev first
 	resp, err := http.Get("https://go.dev/VERSION?m=text")
@@ -183,4 +343,11 @@ func fetchLatestGoVersion() string {
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().StringVar(&initLintProfileFlag, "lint-profile", lint.DefaultProfile, "golangci-lint profile written to .golangci.yml: minimal, standard, strict or security")
+	initCmd.Flags().StringVar(&initTypeFlag, "type", scaffold.DefaultType, "Type of project: cli, lib, http, grpc or worker, see --list-types")
+	initCmd.Flags().BoolVar(&initListTypesFlag, "list-types", false, "List the types of project and exit")
+	initCmd.Flags().StringVar(&initTemplateFlag, "template", strconst.Empty, "External template instead of --type: <path-or-git-url>[//subdir][@ref]")
+	initCmd.Flags().StringArrayVar(&initSetFlag, "set", nil, "Value of a template variable as key=value, asked otherwise, can be repeated")
+	initCmd.Flags().StringVar(&initValuesFlag, "values", strconst.Empty, "YAML file of the values of the template variables by name")
+	initCmd.Flags().StringVar(&initLicenseFlag, "license", strconst.Empty, "SPDX identifier of the license of the project, e.g. MIT, available to the templates")
 }
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/scaffold"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+// externalTemplate is a template given with 'godev init --template'
+type externalTemplate struct {
+	dir      string
+	manifest *scaffold.Manifest
+}
+
+// loadExternalTemplate fetches the template and reads its manifest, nil when it failed
+func loadExternalTemplate(ctx context.Context, source string) *externalTemplate {
+	src, err := scaffold.ParseSource(source)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s", strconst.EmojiFailure, err.Error())))
+		return nil
+	}
+
+	cacheDir, err := scaffold.CacheDir()
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to find the cache directory: %s", strconst.EmojiFailure, err.Error())))
+		return nil
+	}
+	if src.Repo != strconst.Empty {
+		fmt.Printf("%s Fetching template %s\n", strconst.EmojiRunning, src)
+	}
+	fetched, err := scaffold.Fetch(ctx, src, cacheDir)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to fetch template %s: %s", strconst.EmojiFailure, src, err.Error())))
+		return nil
+	}
+	if fetched.UpdateErr != nil {
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Failed to update template %s, using the cached clone at %s: %s", strconst.EmojiWarning, src, fetched.Commit, fetched.UpdateErr.Error())))
+	} else if fetched.Commit != strconst.Empty {
+		fmt.Printf("%s Fetched template %s at %s\n", strconst.EmojiSuccess, src, fetched.Commit)
+	}
+
+	manifest, err := scaffold.LoadManifest(os.DirFS(fetched.Dir))
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to load template %s: %s", strconst.EmojiFailure, src, err.Error())))
+		return nil
+	}
+	return &externalTemplate{dir: fetched.Dir, manifest: manifest}
+}
+
+// unpackExternalTemplate writes the files of the template then runs its post-init hooks
+func unpackExternalTemplate(ctx context.Context, dirAbsPath string, external *externalTemplate, data scaffold.Data) (success bool) {
+	files, err := scaffold.RenderTemplate(os.DirFS(external.dir), external.manifest, data)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to render template: %s", strconst.EmojiFailure, err.Error())))
+		return false
+	}
+	if !writeProjectFiles(dirAbsPath, files) {
+		return false
+	}
+
+	hooks, err := scaffold.RenderHooks(external.manifest, data)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to render the post-init hooks: %s", strconst.EmojiFailure, err.Error())))
+		return false
+	}
+	for _, hook := range hooks {
+		c := osutil.Command{Name: hook[0], Args: hook[1:], Dir: dirAbsPath}
+		if err := osutil.RunCommandContext(ctx, c); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Post-init hook %s failed: %s", strconst.EmojiFailure, c, err.Error())))
+			return false
+		}
+	}
+	return true
+}
@@ -1,42 +1,254 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/lint"
 	"github.com/thought2code/godev/internal/osutil"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
 
+var (
+	lintCheckFlag         bool
+	lintFailFastFlag      bool
+	lintChangedFlag       bool
+	lintBaseFlag          string
+	lintFormatFlag        string
+	lintWriteBaselineFlag bool
+	lintProfileFlag       string
+)
+
+var lintCmdExample = strings.Trim(`
+  godev lint
+  godev lint --check
+  godev lint --fail-fast
+  godev lint --changed
+  godev lint --changed --base main
+  godev lint --check --format checkstyle > lint.xml
+  godev lint --check --format github
+  godev lint --write-baseline
+  godev lint --profile strict
+`, strconst.NewLine)
+
 var lintCmd = &cobra.Command{
 	Use:     "lint",
 	Short:   "Run linters on the codebase",
-	Example: "  godev lint",
+	Example: lintCmdExample,
 	PreRun: func(cmd *cobra.Command, args []string) {
-		runDoctor()
+		// keep the standard output to the report in the other formats
+		if lintFormatFlag == lint.FormatText {
+			runDoctor(nil, nil)
+		}
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := osutil.RunCommand("goimports", "-w", "."); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to run 'goimports -w .': %v", strconst.EmojiFailure, err)))
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if !slices.Contains(lint.Formats, lintFormatFlag) {
+			return fmt.Errorf("invalid format %q, expected one of %s", lintFormatFlag, strings.Join(lint.Formats, ", "))
+		}
+		text := lintFormatFlag == lint.FormatText
+		if lintWriteBaselineFlag && lintChangedFlag {
+			return fmt.Errorf("--write-baseline records the findings of the whole project, it can not be used with --changed")
+		}
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("fail-fast") {
+			lintFailFastFlag = cfg.Lint.FailFast
+		}
+
+		// tools declared in go.mod run with 'go tool' at the version pinned by the module
+		moduleTools, err := gotool.ReadModuleTools("go.mod")
+		if err != nil {
+			return fmt.Errorf("failed to read the tools of go.mod: %w", err)
+		}
+
+		opts := lint.Options{Check: lintCheckFlag}
+		if lintProfileFlag != strconst.Empty {
+			path, remove, err := writeProfileConfig(lintProfileFlag)
+			if err != nil {
+				return err
+			}
+			defer remove()
+			opts.GolangciConfig = path
+		}
+		if lintChangedFlag {
+			changes, err := changedSince(lintBaseFlag)
+			if err != nil {
+				return err
+			}
+			opts.Changed, opts.NewFromRev = true, changes.rev
+			opts.Files, opts.Packages = changes.goFiles, lint.PackagePatterns(changes.goFiles)
+			if text {
+				fmt.Printf("%s Linting %d Go file(s) changed since %s\n", strconst.EmojiRocket, len(opts.Files), lintBaseFlag)
+			}
+		}
+
+		// the baseline is replaced by the current findings when it is written
+		var baseline *lint.Baseline
+		if !lintWriteBaselineFlag {
+			if baseline, err = lint.ReadBaseline(cfg.Lint.Baseline); err != nil {
+				return err
+			}
+		}
+
+		var onResult func(lint.Result)
+		if text {
+			onResult = printLintResult
+		}
+		results := lint.Run(cmd.Context(), lint.Steps(cfg.Lint, opts), lintFailFastFlag, baseline, lintRunner(moduleTools, text), onResult)
+
+		if lintWriteBaselineFlag {
+			baseline = lint.NewBaseline(results)
+			if err := baseline.Write(cfg.Lint.Baseline); err != nil {
+				return fmt.Errorf("failed to write the baseline %s: %w", cfg.Lint.Baseline, err)
+			}
+			for i := range results {
+				results[i] = baseline.Apply(results[i])
+			}
+		}
+
+		fixed := baseline.Fixed()
+		if lintChangedFlag {
+			// only the changed files were linted, the others did not get a chance to match
+			fixed = slices.DeleteFunc(fixed, func(entry lint.BaselineEntry) bool { return !slices.Contains(opts.Files, entry.File) })
 		}
-		if err := osutil.RunCommand("gofumpt", "-w", "."); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to run 'gofumpt -w .': %v", strconst.EmojiFailure, err)))
-			return
+
+		if text {
+			printLintSummary(results)
+			if lintWriteBaselineFlag {
+				fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Recorded %d finding(s) in %s, commit it with the project", strconst.EmojiSuccess, len(baseline.Entries), cfg.Lint.Baseline)))
+			}
+			printFixedBaseline(fixed, cfg.Lint.Baseline)
+		} else if err := lint.WriteReport(os.Stdout, lintFormatFlag, results); err != nil {
+			return fmt.Errorf("failed to write the %s report: %w", lintFormatFlag, err)
 		}
-		if err := osutil.RunCommand("golangci-lint", "run", "./..."); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to run 'golangci-lint run ./...': %v", strconst.EmojiFailure, err)))
-			return
+
+		if lint.Failed(results) {
+			return &ExitError{Code: 1, Err: fmt.Errorf("lint failed: %s", strings.Join(failedLintSteps(results), ", "))}
 		}
-		if err := osutil.RunCommand("go", "mod", "tidy"); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to run 'go mod tidy': %v", strconst.EmojiFailure, err)))
-			return
+		if lintCheckFlag && text {
+			fmt.Println(tui.SuccessStyle(strconst.EmojiSuccess + " Lint check passed"))
 		}
+		return nil
 	},
 }
 
+// lintRunner runs the commands of the steps, streaming their output when printing the text
+// output, except the machine readable reports parsed into findings
+func lintRunner(moduleTools []gotool.ModuleTool, printText bool) lint.Runner {
+	return func(ctx context.Context, step lint.Step, stdout io.Writer) error {
+		command := gotool.Command(moduleTools, step.Command[0], step.Command[1:]...)
+		c := osutil.Command{Name: command[0], Args: command[1:], Stdout: stdout}
+		if !printText {
+			c.Stderr = os.Stderr
+			_, err := osutil.Run(ctx, c)
+			return err
+		}
+		if !step.Report {
+			c.Stdout = io.MultiWriter(os.Stdout, stdout)
+		}
+		return osutil.RunCommandContext(ctx, c)
+	}
+}
+
+func printLintResult(result lint.Result) {
+	printLintFindings(result.Findings)
+	if result.Status != lint.StatusFailed {
+		return
+	}
+	// formatters and tidy in check mode exit with an error when they find something
+	if result.Count > 0 {
+		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s: %d finding(s)", strconst.EmojiFailure, result.Step, result.Count)))
+		return
+	}
+	fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s %s: %v", strconst.EmojiFailure, result.Step, result.Err)))
+}
+
+// printLintFindings prints the findings grouped by file
+func printLintFindings(findings []lint.Finding) {
+	for _, group := range lint.GroupByFile(findings) {
+		fmt.Println(group[0].File)
+		for _, f := range group {
+			style := tui.ErrorStyle
+			if f.Severity != lint.SeverityError {
+				style = tui.WarnStyle
+			}
+			// findings about a whole file, like formatting, have no position
+			position := strconst.Empty
+			if f.Line > 0 {
+				position = fmt.Sprintf("%d:%d ", f.Line, f.Column)
+			}
+			fmt.Printf("  %s%s %s (%s)\n", position, style(f.Severity), f.Message, f.Linter)
+		}
+	}
+}
+
+func printLintSummary(results []lint.Result) {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		status := tui.SuccessStyle(strconst.EmojiSuccess + " " + result.Status)
+		duration := result.Duration.Round(time.Millisecond).String()
+		switch result.Status {
+		case lint.StatusFailed:
+			status = tui.ErrorStyle(strconst.EmojiFailure + " " + result.Status)
+		case lint.StatusSkipped:
+			text := strconst.EmojiWarning + " " + result.Status
+			if result.Reason != strconst.Empty {
+				text += " (" + result.Reason + ")"
+			}
+			status, duration = tui.WarnStyle(text), "-"
+		}
+		findings := strconv.Itoa(result.Count)
+		if result.Baselined > 0 {
+			findings += fmt.Sprintf(" (+%d baselined)", result.Baselined)
+		}
+		rows = append(rows, []string{result.Step, status, duration, findings})
+	}
+	fmt.Println(tui.RenderTable([]string{"Step", "Status", "Duration", "Findings"}, rows))
+}
+
+// printFixedBaseline lists the accepted findings which are gone, so the baseline can shrink
+func printFixedBaseline(fixed []lint.BaselineEntry, path string) {
+	if len(fixed) == 0 {
+		return
+	}
+	fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %d finding(s) of %s are fixed:", strconst.EmojiSuccess, len(fixed), path)))
+	for _, entry := range fixed {
+		fmt.Printf("  %s: %s (%s)\n", entry.File, entry.Message, entry.Linter)
+	}
+	fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Run 'godev lint --write-baseline' to remove them from the baseline", strconst.EmojiTips)))
+}
+
+func failedLintSteps(results []lint.Result) []string {
+	var failed []string
+	for _, result := range results {
+		if result.Status == lint.StatusFailed {
+			failed = append(failed, result.Step)
+		}
+	}
+	return failed
+}
+
 func init() {
+	lintCmd.Flags().BoolVar(&lintCheckFlag, "check", false, "Report unformatted files, lint issues and an untidy go.mod without changing any file")
+	lintCmd.Flags().BoolVar(&lintFailFastFlag, "fail-fast", false, "Stop at the first failed step instead of running all of them")
+	lintCmd.Flags().StringVar(&lintFormatFlag, "format", lint.FormatText, "Output format: "+strings.Join(lint.Formats, ", "))
+	lintCmd.Flags().BoolVar(&lintWriteBaselineFlag, "write-baseline", false, "Record the current findings in the baseline file, only new findings fail the lint afterwards")
+	lintCmd.Flags().StringVar(&lintProfileFlag, "profile", strconst.Empty, "Lint with the linters of a profile instead of .golangci.yml: minimal, standard, strict or security")
+	addChangedFlags(lintCmd, &lintChangedFlag, &lintBaseFlag)
 	rootCmd.AddCommand(lintCmd)
 }
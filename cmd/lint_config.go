@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/lint"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+// golangciConfigFiles are the golangci-lint configuration files godev reads and upgrades
+var golangciConfigFiles = []string{".golangci.yml", ".golangci.yaml"}
+
+var lintConfigCmdExample = strings.Trim(`
+  godev lint config upgrade
+  godev lint config upgrade --profile strict
+`, strconst.NewLine)
+
+var lintConfigProfileFlag string
+
+var lintConfigCmd = &cobra.Command{
+	Use:     "config",
+	Short:   "Manage the golangci-lint configuration of the project",
+	Example: lintConfigCmdExample,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to get help: %s", strconst.EmojiFailure, err.Error())))
+			return
+		}
+	},
+}
+
+var lintConfigUpgradeCmd = &cobra.Command{
+	Use:     "upgrade",
+	Short:   "Upgrade .golangci.yml to the latest revision of its lint profile, keeping the local edits",
+	Example: lintConfigCmdExample,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		path, data, err := readGolangciConfig()
+		if err != nil {
+			return err
+		}
+		if path == strconst.Empty {
+			return fmt.Errorf("no %s found, 'godev init' writes one", golangciConfigFiles[0])
+		}
+
+		upgraded, upgrade, err := lint.UpgradeConfig(data, lintConfigProfileFlag)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade %s: %w", path, err)
+		}
+		if upgrade.UpToDate() {
+			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s is up to date with the %s profile revision %d", strconst.EmojiSuccess, path, upgrade.To.Name, upgrade.To.Revision)))
+			return nil
+		}
+		if err := osutil.WriteFile(path, upgraded, 0o644); err != nil {
+			return err
+		}
+
+		if len(upgrade.Added) > 0 {
+			fmt.Printf("%s Enabled linters: %s\n", strconst.EmojiSuccess, strings.Join(upgrade.Added, ", "))
+		}
+		if len(upgrade.Removed) > 0 {
+			fmt.Printf("%s Disabled linters: %s\n", strconst.EmojiSuccess, strings.Join(upgrade.Removed, ", "))
+		}
+		for _, kept := range upgrade.Kept {
+			fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Kept local edit: %s", strconst.EmojiTips, kept)))
+		}
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Upgraded %s from the %s profile revision %d to the %s profile revision %d",
+			strconst.EmojiSuccess, path, upgrade.From.Name, upgrade.From.Revision, upgrade.To.Name, upgrade.To.Revision)))
+		return nil
+	},
+}
+
+// readGolangciConfig reads the golangci-lint configuration of the project, an empty path when
+// there is none
+func readGolangciConfig() (string, []byte, error) {
+	for _, path := range golangciConfigFiles {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return path, data, err
+	}
+	return strconst.Empty, nil, nil
+}
+
+// writeProfileConfig writes the configuration of the project with the linters of the profile
+// to a temporary file, removed by the returned function
+func writeProfileConfig(name string) (string, func(), error) {
+	profile, err := lint.FindProfile(name)
+	if err != nil {
+		return strconst.Empty, nil, err
+	}
+	_, data, err := readGolangciConfig()
+	if err != nil {
+		return strconst.Empty, nil, err
+	}
+	data, err = lint.ApplyProfile(data, profile)
+	if err != nil {
+		return strconst.Empty, nil, err
+	}
+
+	file, err := os.CreateTemp(strconst.Empty, "godev-golangci-*.yml")
+	if err != nil {
+		return strconst.Empty, nil, err
+	}
+	remove := func() { _ = os.Remove(file.Name()) }
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		remove()
+		return strconst.Empty, nil, err
+	}
+	if err := file.Close(); err != nil {
+		remove()
+		return strconst.Empty, nil, err
+	}
+	return file.Name(), remove, nil
+}
+
+func init() {
+	lintCmd.AddCommand(lintConfigCmd)
+	lintConfigCmd.AddCommand(lintConfigUpgradeCmd)
+	lintConfigUpgradeCmd.Flags().StringVar(&lintConfigProfileFlag, "profile", strconst.Empty, "Switch to another profile: minimal, standard, strict or security (default the current one)")
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gitutil"
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/release"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var releaseCmdExample = strings.Trim(`
+  godev release
+  godev release --version v1.2.0
+  godev release --platforms linux/amd64,darwin/arm64 --format zip
+  godev release --sbom=false --changelog=false
+`, strconst.NewLine)
+
+// files shipped in every archive next to the binaries when they exist in the project
+var releaseExtraFiles = []string{"LICENSE", "README.md"}
+
+var (
+	releaseFormatFlag    string
+	releaseSBOMFlag      bool
+	releaseChangelogFlag bool
+)
+
+var releaseCmd = &cobra.Command{
+	Use:     "release [packages...]",
+	Short:   "Build and package release archives with checksums and changelog",
+	Example: releaseCmdExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		applyBuildConfig(cmd, cfg, cfg.Build.Release.Platforms)
+		if !cmd.Flags().Changed("format") {
+			releaseFormatFlag = cfg.Build.Release.Format
+		}
+		if !cmd.Flags().Changed("sbom") {
+			releaseSBOMFlag = cfg.Build.Release.SBOM
+		}
+		if !cmd.Flags().Changed("changelog") {
+			releaseChangelogFlag = cfg.Build.Release.Changelog
+		}
+
+		if releaseFormatFlag != "auto" && releaseFormatFlag != release.FormatTarGz && releaseFormatFlag != release.FormatZip {
+			return fmt.Errorf("invalid archive format %q, expected auto, %s or %s", releaseFormatFlag, release.FormatTarGz, release.FormatZip)
+		}
+
+		opts, err := buildOptionsFromFlags(args)
+		if err != nil {
+			return err
+		}
+
+		modulePath, err := gobuild.ModulePath()
+		if err != nil {
+			return fmt.Errorf("failed to get module path: %w", err)
+		}
+		project := gobuild.BinaryName(modulePath, strconst.Empty)
+
+		if err := osutil.RemoveDirIfExist(opts.OutputDir); err != nil {
+			return fmt.Errorf("failed to remove output directory: %w", err)
+		}
+
+		fmt.Printf("%s Releasing %s %s for %d platform(s)...\n", strconst.EmojiRocket, project, opts.Version, len(opts.Platforms))
+		artifacts, err := gobuild.Build(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		archives, err := createReleaseArchives(project, opts, artifacts)
+		if err != nil {
+			return fmt.Errorf("failed to create release archives: %w", err)
+		}
+		outputs := append([]string(nil), archives...)
+
+		checksums := filepath.Join(opts.OutputDir, "checksums.txt")
+		if osutil.DryRun() {
+			osutil.PlanWrite(checksums, "sha256 checksums of the archives")
+		} else if err := release.WriteChecksums(checksums, archives); err != nil {
+			return fmt.Errorf("failed to write checksums: %w", err)
+		}
+		outputs = append(outputs, checksums)
+
+		if releaseSBOMFlag {
+			sbom := filepath.Join(opts.OutputDir, "sbom.json")
+			if err := writeReleaseSBOM(sbom, artifacts); err != nil {
+				return fmt.Errorf("failed to write SBOM manifest: %w", err)
+			}
+			outputs = append(outputs, sbom)
+		}
+
+		if releaseChangelogFlag {
+			changelog := filepath.Join(opts.OutputDir, "CHANGELOG.md")
+			if err := writeReleaseChangelog(changelog, opts.Version); err != nil {
+				return fmt.Errorf("failed to write changelog: %w", err)
+			}
+			outputs = append(outputs, changelog)
+		}
+
+		printReleaseFiles(outputs)
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Release %s %s is ready in %s", strconst.EmojiSuccess, project, opts.Version, opts.OutputDir)))
+		return nil
+	},
+}
+
+// createReleaseArchives packs the binaries of each platform into one archive
+func createReleaseArchives(project string, opts gobuild.Options, artifacts []gobuild.Artifact) ([]string, error) {
+	var extras []string
+	for _, file := range releaseExtraFiles {
+		if exist, err := osutil.CheckExist(file); err == nil && exist {
+			extras = append(extras, file)
+		}
+	}
+
+	var archives []string
+	for _, platform := range opts.Platforms {
+		files := make([]string, 0, len(opts.Packages)+len(extras))
+		for _, a := range artifacts {
+			if a.Platform == platform {
+				files = append(files, a.Path)
+			}
+		}
+		files = append(files, extras...)
+
+		format := release.ArchiveFormat(platform.GOOS, releaseFormatFlag)
+		archive := filepath.Join(opts.OutputDir, release.ArchiveName(project, opts.Version, platform.GOOS, platform.GOARCH, format))
+		if osutil.DryRun() {
+			osutil.PlanWrite(archive, format+" archive of "+strings.Join(files, strconst.Space))
+		} else if err := release.CreateArchive(archive, format, files); err != nil {
+			return nil, fmt.Errorf("%s: %w", archive, err)
+		}
+		archives = append(archives, archive)
+	}
+	return archives, nil
+}
+
+// writeReleaseSBOM records the module dependencies of every released main package
+func writeReleaseSBOM(dest string, artifacts []gobuild.Artifact) error {
+	// the dependencies are read from the binaries, which are not built in dry-run mode
+	if osutil.DryRun() {
+		osutil.PlanWrite(dest, "module dependencies of the released binaries")
+		return nil
+	}
+
+	var manifests []*release.Manifest
+	seen := make(map[string]bool)
+	for _, a := range artifacts {
+		if seen[a.Package] {
+			continue
+		}
+		seen[a.Package] = true
+
+		manifest, err := release.ReadManifest(a.Path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.Path, err)
+		}
+		manifest.Binary = a.Name
+		manifests = append(manifests, manifest)
+	}
+	return release.WriteManifests(dest, manifests)
+}
+
+func writeReleaseChangelog(dest, version string) error {
+	tags, err := gitutil.Tags()
+	if err != nil {
+		return err
+	}
+
+	commits, err := gitutil.Log(release.ChangelogRange(version, tags))
+	if err != nil {
+		return err
+	}
+
+	changelog := release.RenderChangelog(version, time.Now().UTC().Format(strconst.ProjectBuildTimeFormat), commits)
+	return osutil.WriteFile(dest, []byte(changelog), 0o644)
+}
+
+func printReleaseFiles(files []string) {
+	rows := make([][]string, 0, len(files))
+	for _, file := range files {
+		size := "-"
+		if info, err := os.Stat(file); err == nil {
+			size = tui.FormatSize(info.Size())
+		}
+		rows = append(rows, []string{file, size})
+	}
+	fmt.Println(tui.RenderTable([]string{"Release File", "Size"}, rows))
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	addBuildFlags(releaseCmd, config.Default().Build.Release.Platforms)
+	releaseCmd.Flags().StringVar(&releaseFormatFlag, "format", "auto", "Archive format: auto (zip for windows, tar.gz otherwise), tar.gz or zip")
+	releaseCmd.Flags().BoolVar(&releaseSBOMFlag, "sbom", true, "Write an SBOM-style manifest of the module dependencies")
+	releaseCmd.Flags().BoolVar(&releaseChangelogFlag, "changelog", true, "Generate a changelog from the git tags and commit messages")
+}
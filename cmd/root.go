@@ -1,20 +1,35 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/osutil"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
 
+var (
+	dryRunFlag  bool
+	explainFlag bool
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "godev",
 	Short: "godev - A modern Go development kit",
+	// errors are printed by main.go
+	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		osutil.SetDryRun(dryRunFlag, explainFlag)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := cmd.Help(); err != nil {
 			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to get help: %s", strconst.EmojiFailure, err.Error())))
@@ -24,20 +39,61 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	// cancelled by Ctrl-C, interrupting the commands godev runs
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	// the script of --explain, printed in one piece after the messages of the command
+	if scriptErr := osutil.WriteScript(os.Stdout); err == nil {
+		err = scriptErr
+	}
+	return err
 }
 
-func init() {
-	rootCmd.Version = strconst.ProjectVersion
+// ExitError makes godev exit with Code, e.g. to let CI tell failures from warnings
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// SetBuildInfo sets the version metadata shown by 'godev --version', called from main.go with
+// the values injected at build time via -ldflags, e.g. by 'godev build'
+func SetBuildInfo(version, commit, date string) {
+	if version == strconst.Empty {
+		version = strconst.ProjectVersion
+	}
+	if commit == strconst.Empty {
+		commit = "unknown"
+	}
+	if date == strconst.Empty {
+		date = time.Now().UTC().Format(strconst.ProjectBuildTimeFormat)
+	}
+
+	rootCmd.Version = version
 
 	versionTemplateFormat := strings.Trim(strconst.ProjectVersionTemplateFormat, strconst.NewLine)
-	buildTime := time.Now().UTC().Format(strconst.ProjectBuildTimeFormat)
 	versionTemplate := fmt.Sprintf(
 		versionTemplateFormat,
-		buildTime,
+		date,
+		commit,
 		runtime.Version(),
 		runtime.GOOS,
 		runtime.GOARCH)
 
 	rootCmd.SetVersionTemplate(tui.SuccessStyle(versionTemplate))
 }
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the commands and file writes instead of running them")
+	rootCmd.PersistentFlags().BoolVar(&explainFlag, "explain", false, "Print the equivalent shell script instead of running it, implies --dry-run")
+	SetBuildInfo(strconst.ProjectVersion, strconst.Empty, strconst.Empty)
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/osutil"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
@@ -15,6 +21,12 @@ var testCmdExample = strings.Trim(`
   godev test integ
 `, strconst.NewLine)
 
+var (
+	verboseFlag    bool
+	coverageFlag   bool
+	htmlReportFlag bool
+)
+
 var testCmd = &cobra.Command{
 	Use:     "test",
 	Short:   "Run tests for the project",
@@ -27,6 +39,67 @@ var testCmd = &cobra.Command{
 	},
 }
 
+// goTestRun describes one 'go test' invocation shared by the unit and integration test commands
+type goTestRun struct {
+	kind        string
+	coverageDir string
+	args        []string
+	env         []string
+	// packages to test, all of the module when empty
+	packages []string
+}
+
+// runGoTests runs the tests, a failure of 'go test' being an ExitError with its exit code so
+// that CI can tell the tests failed
+func runGoTests(ctx context.Context, run goTestRun) error {
+	if err := osutil.RemoveDirIfExist(run.coverageDir); err != nil {
+		return fmt.Errorf("failed to remove coverage directory: %w", err)
+	}
+
+	if err := osutil.MkdirAll(run.coverageDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create coverage directory: %w", err)
+	}
+
+	coverprofile := filepath.Join(run.coverageDir, "coverprofile")
+
+	testArgs := []string{"test"}
+	if verboseFlag {
+		testArgs = append(testArgs, "-v")
+	}
+	if coverageFlag || htmlReportFlag {
+		testArgs = append(testArgs, "-coverprofile", coverprofile)
+	}
+	testArgs = append(testArgs, run.args...)
+	if len(run.packages) > 0 {
+		testArgs = append(testArgs, run.packages...)
+	} else {
+		testArgs = append(testArgs, "./...")
+	}
+
+	if err := osutil.RunCommandContext(ctx, osutil.Command{Name: "go", Args: testArgs, Env: run.env}); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &ExitError{Code: exitErr.ExitCode(), Err: fmt.Errorf("%s tests failed: %w", run.kind, err)}
+		}
+		return fmt.Errorf("failed to run %s tests: %w", run.kind, err)
+	}
+
+	if htmlReportFlag {
+		html := filepath.Join(run.coverageDir, "cover.html")
+		coverCommand := osutil.Command{Name: "go", Args: []string{"tool", "cover", "-html", coverprofile, "-o", html}}
+		if err := osutil.RunCommandContext(ctx, coverCommand); err != nil {
+			return fmt.Errorf("failed to generate HTML coverage report: %w", err)
+		}
+		if runtime.GOOS == "windows" {
+			if err := osutil.RunCommandContext(ctx, osutil.Command{Name: "cmd", Args: []string{"/c", "start", html}}); err != nil {
+				return fmt.Errorf("failed to open HTML coverage report: %w", err)
+			}
+		}
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s HTML coverage report generated: %s", strconst.EmojiSuccess, html)))
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(testCmd)
 }
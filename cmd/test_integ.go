@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+var integTestCmdExample = strings.Trim(`
+  godev test integ
+  godev test integ -v -c
+  godev test integ --tags e2e --timeout 1h
+  godev test integ --serial --env DB_DSN=postgres://localhost:5432/test
+`, strconst.NewLine)
+
+var (
+	integTagsFlag    string
+	integTimeoutFlag time.Duration
+	integSerialFlag  bool
+	integEnvFlag     []string
+)
+
+var integTestCmd = &cobra.Command{
+	Use:     "integ [-v] [-c] [--html] [--tags tag] [--timeout duration] [--serial] [--env KEY=VALUE]",
+	Short:   "Run integration tests for the project",
+	Example: integTestCmdExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+
+		// the flags take precedence over godev.yaml
+		if !cmd.Flags().Changed("tags") {
+			integTagsFlag = cfg.Test.Integ.Tags
+		}
+		if !cmd.Flags().Changed("timeout") {
+			integTimeoutFlag, _ = time.ParseDuration(cfg.Test.Integ.Timeout)
+		}
+		if !cmd.Flags().Changed("serial") {
+			integSerialFlag = cfg.Test.Integ.Serial
+		}
+
+		for _, kv := range integEnvFlag {
+			if key, _, ok := strings.Cut(kv, "="); !ok || key == strconst.Empty {
+				return fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", kv)
+			}
+		}
+
+		testArgs := []string{"-count=1", "-timeout", integTimeoutFlag.String()}
+		if integTagsFlag != strconst.Empty {
+			testArgs = append(testArgs, "-tags", integTagsFlag)
+		}
+		if integSerialFlag {
+			testArgs = append(testArgs, "-p", "1")
+		}
+
+		env := make([]string, 0, len(cfg.Test.Integ.Env)+len(integEnvFlag))
+		for _, key := range slices.Sorted(maps.Keys(cfg.Test.Integ.Env)) {
+			env = append(env, key+"="+cfg.Test.Integ.Env[key])
+		}
+		env = append(env, integEnvFlag...)
+
+		return runGoTests(cmd.Context(), goTestRun{
+			kind:        "integration",
+			coverageDir: filepath.Join(cfg.Test.CoverageDir, "integ"),
+			args:        testArgs,
+			env:         env,
+		})
+	},
+}
+
+func init() {
+	testCmd.AddCommand(integTestCmd)
+	integTestCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
+	integTestCmd.Flags().BoolVarP(&coverageFlag, "cover", "c", false, "Enable code coverage")
+	integTestCmd.Flags().BoolVar(&htmlReportFlag, "html", false, "Generate and open HTML coverage report")
+	integTestCmd.Flags().StringVar(&integTagsFlag, "tags", "integration", "Build tag that guards the integration tests")
+	integTestCmd.Flags().DurationVar(&integTimeoutFlag, "timeout", 30*time.Minute, "Timeout for the whole integration test run")
+	integTestCmd.Flags().BoolVar(&integSerialFlag, "serial", false, "Run test packages one at a time (-p 1)")
+	integTestCmd.Flags().StringArrayVar(&integEnvFlag, "env", nil, "Environment variable passed to the tests as KEY=VALUE (repeatable), added to test.integ.env of godev.yaml")
+}
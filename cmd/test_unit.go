@@ -2,18 +2,20 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/gobuild"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
 
+var (
+	unitChangedFlag bool
+	unitBaseFlag    string
+)
+
 var unitTestCmdExample = strings.Trim(`
   godev test unit
   godev test unit -v
@@ -21,61 +23,39 @@ var unitTestCmdExample = strings.Trim(`
   godev test unit -v -c
   godev test unit --html
   godev test unit -v --html
+  godev test unit --changed
+  godev test unit --changed --base main
 `, strconst.NewLine)
 
-var (
-	verboseFlag    bool
-	coverageFlag   bool
-	htmlReportFlag bool
-)
-
 var unitTestCmd = &cobra.Command{
-	Use:     "unit [-v] [-c] [--html]",
+	Use:     "unit [-v] [-c] [--html] [--changed]",
 	Short:   "Run unit tests for the project",
 	Example: unitTestCmdExample,
-	Run: func(cmd *cobra.Command, args []string) {
-		testCoverageDir := "coverage"
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 
-		if err := osutil.RemoveDirIfExist(testCoverageDir); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to remove coverage directory: %s", strconst.EmojiFailure, err.Error())))
-			return
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
 		}
 
-		if err := os.MkdirAll(testCoverageDir, 0o755); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to create coverage directory: %s", strconst.EmojiFailure, err.Error())))
-			return
+		run := goTestRun{
+			kind:        "unit",
+			coverageDir: cfg.Test.CoverageDir,
 		}
-
-		coverprofile := filepath.Join(testCoverageDir, "coverprofile")
-
-		testArgs := []string{"test"}
-		if verboseFlag {
-			testArgs = append(testArgs, "-v")
-		}
-		if coverageFlag || htmlReportFlag {
-			testArgs = append(testArgs, "-coverprofile", coverprofile)
-		}
-		testArgs = append(testArgs, "./...")
-
-		if err := osutil.RunCommand("go", testArgs...); err != nil {
-			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to run unit tests: %s", strconst.EmojiFailure, err.Error())))
-			return
-		}
-
-		if htmlReportFlag {
-			html := filepath.Join(testCoverageDir, "cover.html")
-			if err := osutil.RunCommand("go", "tool", "cover", "-html", coverprofile, "-o", html); err != nil {
-				fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to generate HTML coverage report: %s", strconst.EmojiFailure, err.Error())))
-				return
+		if unitChangedFlag {
+			packages, err := affectedPackages(unitBaseFlag)
+			if err != nil {
+				return err
 			}
-			if runtime.GOOS == "windows" {
-				if err := osutil.RunCommand("cmd", "/c", "start", html); err != nil {
-					fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to open HTML coverage report: %s", strconst.EmojiFailure, err.Error())))
-					return
-				}
+			if len(packages) == 0 {
+				fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s No package changed since %s, nothing to test", strconst.EmojiSuccess, unitBaseFlag)))
+				return nil
 			}
-			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s HTML coverage report generated: %s", strconst.EmojiSuccess, html)))
+			fmt.Printf("%s Testing %d package(s) affected by the changes since %s\n", strconst.EmojiRocket, len(packages), unitBaseFlag)
+			run.packages = packages
 		}
+		return runGoTests(cmd.Context(), run)
 	},
 }
 
@@ -84,4 +64,18 @@ func init() {
 	unitTestCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
 	unitTestCmd.Flags().BoolVarP(&coverageFlag, "cover", "c", false, "Enable code coverage")
 	unitTestCmd.Flags().BoolVar(&htmlReportFlag, "html", false, "Generate and open HTML coverage report")
+	addChangedFlags(unitTestCmd, &unitChangedFlag, &unitBaseFlag)
+}
+
+// affectedPackages lists the packages changed since the base and the ones depending on them
+func affectedPackages(base string) ([]string, error) {
+	changes, err := changedSince(base)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := gobuild.ListPackages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the packages: %w", err)
+	}
+	return gobuild.AffectedPackages(packages, changes.files), nil
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gotool"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
@@ -12,7 +14,7 @@ import (
 var toolsCmd = &cobra.Command{
 	Use:     "tools",
 	Short:   "Manage Go tools",
-	Example: "  godev tools install",
+	Example: "  godev tools install\n  godev tools list",
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := cmd.Help(); err != nil {
 			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to get help: %s", strconst.EmojiFailure, err.Error())))
@@ -21,6 +23,29 @@ var toolsCmd = &cobra.Command{
 	},
 }
 
+// projectTools returns the tools configured in godev.yaml pinned by the tool directives of go.mod
+func projectTools() ([]config.ToolConfig, error) {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	tools, err := gotool.ProjectTools(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tools of go.mod: %w", err)
+	}
+	return tools, nil
+}
+
+// findTool returns the tool with the given name or package path
+func findTool(tools []config.ToolConfig, nameOrPackage string) (config.ToolConfig, bool) {
+	for _, tool := range tools {
+		if tool.Name == nameOrPackage || tool.Package == nameOrPackage {
+			return tool, true
+		}
+	}
+	return config.ToolConfig{}, false
+}
+
 func init() {
 	rootCmd.AddCommand(toolsCmd)
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var toolsAddCmdExample = strings.Trim(`
+  godev tools add mvdan.cc/gofumpt
+  godev tools add github.com/golangci/golangci-lint/v2/cmd/golangci-lint@v2.7.2
+`, strconst.NewLine)
+
+var toolsAddCmd = &cobra.Command{
+	Use:     "add <tool-package-path>[@version]...",
+	Short:   "Add Go tools to the tool directives of go.mod",
+	Example: toolsAddCmdExample,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		cfg, err := loadProjectConfig()
+		if err != nil {
+			return err
+		}
+		moduleTools, err := gotool.ReadModuleTools("go.mod")
+		if err != nil {
+			return err
+		}
+
+		for _, arg := range args {
+			pkg, version, _ := strings.Cut(arg, "@")
+			if version == strconst.Empty {
+				version = toolVersion(cfg, pkg)
+			}
+
+			if tool, ok := gotool.Find(moduleTools, pkg); ok && tool.Version == version {
+				fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s %s is already a tool of the module", strconst.EmojiSuccess, pkg, version)))
+				continue
+			}
+
+			// go get resolves the module providing the package and adds both the tool and require directives
+			fmt.Printf("🔧 Adding %s %s...\n", pkg, version)
+			command := osutil.Command{Name: "go", Args: []string{"get", "-tool", fmt.Sprintf("%s@%s", pkg, version)}}
+			if err := osutil.RunCommandContext(cmd.Context(), command); err != nil {
+				return fmt.Errorf("failed to add %s %s: %w", pkg, version, err)
+			}
+			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Added %s %s, run it with 'go tool %s'", strconst.EmojiSuccess, pkg, version, gobuild.BinaryName(pkg, strconst.Empty))))
+		}
+		return nil
+	},
+}
+
+// toolVersion returns the version configured for a known tool package, latest otherwise
+func toolVersion(cfg *config.Config, pkg string) string {
+	for _, tool := range cfg.Tools {
+		if tool.Package == pkg && tool.Version != strconst.Empty {
+			return tool.Version
+		}
+	}
+	return "latest"
+}
+
+func init() {
+	toolsCmd.AddCommand(toolsAddCmd)
+}
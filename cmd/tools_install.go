@@ -1,55 +1,213 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gotool"
 	"github.com/thought2code/godev/internal/osutil"
 	"github.com/thought2code/godev/internal/strconst"
 	"github.com/thought2code/godev/internal/tui"
 )
 
+var (
+	toolsInstallJobsFlag  int
+	toolsInstallLocalFlag bool
+)
+
 var toolsInstallCmdExample = strings.Trim(`
   godev tools install
   godev tools install golang.org/x/tools/cmd/goimports
+  godev tools install mvdan.cc/gofumpt@v0.9.2
+  godev tools install --jobs 1
+  godev tools install --local
 `, strconst.NewLine)
 
 var toolsInstallCmd = &cobra.Command{
-	Use:     "install [tool-package-path]",
+	Use:     "install [tool-package-path[@version]]",
 	Short:   "Install Go tools",
 	Example: toolsInstallCmdExample,
 	Args:    cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		var toolPkgPath string
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		var tools []config.ToolConfig
 		if len(args) > 0 {
-			installGoTools(toolPkgPath, strconst.RecommendedGofumptVersion)
+			cfg, err := loadProjectConfig()
+			if err != nil {
+				return err
+			}
+			toolPkgPath, toolVer, _ := strings.Cut(args[0], "@")
+			if toolVer == strconst.Empty {
+				toolVer = toolVersion(cfg, toolPkgPath)
+			}
+			tools = append(tools, config.ToolConfig{Name: toolPkgPath, Package: toolPkgPath, Version: toolVer})
 		} else {
 			fmt.Print(tui.WarnStyle(strconst.EmojiTips + " No tool package path provided. Install recommended tools? (Y/n): "))
 			if confirm, err := tui.ReadUserInput(); err != nil {
-				fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to read input: %s", strconst.EmojiFailure, err.Error())))
-				return
+				return fmt.Errorf("failed to read input: %w", err)
 			} else if confirm != "Y" && confirm != "y" {
 				fmt.Println(tui.WarnStyle(strconst.EmojiWarning + " godev tools install cancelled"))
-				return
+				return nil
+			}
+
+			projectTools, err := projectTools()
+			if err != nil {
+				return err
+			}
+			for _, tool := range projectTools {
+				if tool.Source == config.ToolSourceGoMod {
+					fmt.Printf("%s %s is a tool of go.mod, run it with 'go tool %s'\n", strconst.EmojiTips, tool.Package, tool.Name)
+					continue
+				}
+				tools = append(tools, tool)
+			}
+		}
+
+		var env []string
+		if toolsInstallLocalFlag {
+			gobin, err := gotool.LocalGOBIN()
+			if err != nil {
+				return err
 			}
-			installGoTools(strconst.Gofumpt, strconst.RecommendedGofumptVersion)
-			installGoTools(strconst.Goimports, strconst.RecommendedGoimportsVersion)
-			installGoTools(strconst.GolangciLint, strconst.RecommendedGolangciLintVersion)
+			env = append(env, "GOBIN="+gobin)
 		}
+		return installToolsConcurrently(cmd.Context(), env, tools, toolsInstallJobsFlag)
 	},
 }
 
-func installGoTools(toolName, toolVersion string) {
+// toolInstall is the outcome of installing one tool
+type toolInstall struct {
+	tool     config.ToolConfig
+	output   string
+	err      error
+	duration time.Duration
+}
+
+// installToolsConcurrently runs 'go install' for the tools with at most jobs installs at a time,
+// showing the progress of each and a summary of all of them, env is appended to the environment
+func installToolsConcurrently(ctx context.Context, env []string, tools []config.ToolConfig, jobs int) error {
+	if len(tools) == 0 {
+		return nil
+	}
+	jobs = max(1, min(jobs, len(tools)))
+
+	// the runner only prints the commands, without progress to show
+	if osutil.DryRun() {
+		for _, tool := range tools {
+			command := osutil.Command{Name: "go", Args: []string{"install", fmt.Sprintf("%s@%s", tool.Package, tool.Version)}, Env: env}
+			if _, err := osutil.Run(ctx, command); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = fmt.Sprintf("%s@%s", tool.Package, tool.Version)
+	}
+	progress := tui.NewProgress(os.Stdout, names)
+	progress.Start()
+
+	results := make([]toolInstall, len(tools))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				name := names[i]
+				progress.Run(name, "installing...")
+				lines := osutil.NewLineWriter(func(line string) { progress.Update(name, line) })
+				result, err := osutil.Run(ctx, osutil.Command{
+					Name:    "go",
+					Args:    []string{"install", name},
+					Env:     env,
+					Stdout:  lines,
+					Stderr:  lines,
+					Capture: true,
+				})
+				lines.Flush()
+				results[i] = toolInstall{tool: tools[i], output: result.Stdout + result.Stderr, err: err, duration: result.Duration}
+				progress.Finish(name, err)
+			}
+		}()
+	}
+	for i := range tools {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	progress.Stop()
+
+	return printInstallSummary(results)
+}
+
+func printInstallSummary(results []toolInstall) error {
+	var failed []string
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		status := tui.SuccessStyle(strconst.EmojiSuccess + " installed")
+		if result.err != nil {
+			status = tui.ErrorStyle(strconst.EmojiFailure + " failed")
+			failed = append(failed, result.tool.Package)
+		}
+		rows = append(rows, []string{result.tool.Package, result.tool.Version, status, result.duration.Round(time.Millisecond).String()})
+	}
+	fmt.Println(tui.RenderTable([]string{"Tool", "Version", "Status", "Duration"}, rows))
+
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to install %s %s: %v", strconst.EmojiFailure, result.tool.Package, result.tool.Version, result.err)))
+			fmt.Print(result.output)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New("failed to install " + strings.Join(failed, ", "))
+	}
+	fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Successfully installed %d tool(s)", strconst.EmojiSuccess, len(results))))
+	return nil
+}
+
+// installProjectTool installs the tool where the project keeps its tools, which is the
+// project-local bin directory once it exists and GOBIN otherwise
+func installProjectTool(tool config.ToolConfig) error {
+	var env []string
+	gobin, err := gotool.LocalGOBIN()
+	if err != nil {
+		return err
+	}
+	if exist, _ := osutil.CheckExist(gobin); exist {
+		env = append(env, "GOBIN="+gobin)
+	}
+	return installGoTools(context.Background(), env, tool.Package, tool.Version)
+}
+
+// installGoTools runs 'go install' for one tool, env is appended to the environment
+func installGoTools(ctx context.Context, env []string, toolName, toolVersion string) error {
 	fmt.Printf("🔧 Installing %s %s...\n", toolName, toolVersion)
-	if err := osutil.RunCommand("go", "install", fmt.Sprintf("%s@%s", toolName, toolVersion)); err != nil {
+	command := osutil.Command{Name: "go", Args: []string{"install", fmt.Sprintf("%s@%s", toolName, toolVersion)}, Env: env}
+	if err := osutil.RunCommandContext(ctx, command); err != nil {
 		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to install %s %s: %v", strconst.EmojiFailure, toolName, toolVersion, err)))
-		return
+		return err
 	}
 	fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Successfully installed %s %s", strconst.EmojiSuccess, toolName, toolVersion)))
+	return nil
 }
 
 func init() {
+	toolsInstallCmd.Flags().IntVarP(&toolsInstallJobsFlag, "jobs", "j", min(4, runtime.NumCPU()), "Number of tools to install concurrently")
+	toolsInstallCmd.Flags().BoolVar(&toolsInstallLocalFlag, "local", false, "Install into the project-local "+gotool.LocalBinDir+" instead of GOBIN")
 	toolsCmd.AddCommand(toolsInstallCmd)
 }
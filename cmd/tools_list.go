@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var toolsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the Go tools of the project with their installed and pinned versions",
+	Example: "  godev tools list",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		tools, err := projectTools()
+		if err != nil {
+			return err
+		}
+		env, err := gotool.ReadEnv()
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, 0, len(tools))
+		for _, tool := range tools {
+			path, installed := "-", "not installed"
+			if tool.Source == config.ToolSourceGoMod {
+				path, installed = "go tool "+tool.Name, tool.Version
+			} else if binary, err := gotool.LookBinary(env, tool.Name); err == nil {
+				path, installed = binary.Path, binary.Version+" ("+binary.GoVersion+")"
+			}
+			rows = append(rows, []string{tool.Name, tool.Package, path, installed, fmt.Sprintf("%s (%s)", tool.Version, tool.Source)})
+		}
+		fmt.Println(tui.RenderTable([]string{"Tool", "Package", "Path", "Installed", "Pinned"}, rows))
+		return nil
+	},
+}
+
+func init() {
+	toolsCmd.AddCommand(toolsListCmd)
+}
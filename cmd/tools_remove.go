@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var toolsRemoveCmdExample = strings.Trim(`
+  godev tools remove gofumpt
+  godev tools remove mvdan.cc/gofumpt
+`, strconst.NewLine)
+
+var toolsRemoveCmd = &cobra.Command{
+	Use:     "remove <tool-name|tool-package-path>...",
+	Short:   "Remove Go tools from the tool directives of go.mod",
+	Example: toolsRemoveCmdExample,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		for _, arg := range args {
+			pkg, err := gotool.RemoveTool("go.mod", arg)
+			if err != nil {
+				return err
+			}
+			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Removed the tool directive of %s", strconst.EmojiSuccess, pkg)))
+		}
+		fmt.Println(tui.WarnStyle(strconst.EmojiTips + " Run 'go mod tidy' to drop the requirements no longer needed"))
+		return nil
+	},
+}
+
+func init() {
+	toolsCmd.AddCommand(toolsRemoveCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var toolsUninstallLocalFlag bool
+
+var toolsUninstallCmdExample = strings.Trim(`
+  godev tools uninstall gofumpt
+  godev tools uninstall golang.org/x/tools/cmd/goimports
+  godev tools uninstall --local golangci-lint
+`, strconst.NewLine)
+
+var toolsUninstallCmd = &cobra.Command{
+	Use:     "uninstall <tool-name|tool-package-path>...",
+	Short:   "Remove Go tool binaries from GOBIN or the project-local bin directory",
+	Example: toolsUninstallCmdExample,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		env, err := gotool.ReadEnv()
+		if err != nil {
+			return err
+		}
+		if toolsUninstallLocalFlag {
+			if env.GOBIN, err = gotool.LocalGOBIN(); err != nil {
+				return err
+			}
+		}
+
+		for _, arg := range args {
+			path := env.BinaryPath(gobuild.BinaryName(arg, strconst.Empty))
+			exist, err := osutil.CheckExist(path)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				return fmt.Errorf("%s is not installed in %s", arg, env.GOBIN)
+			}
+			if err := osutil.Remove(path); err != nil {
+				return err
+			}
+			fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s Removed %s", strconst.EmojiSuccess, path)))
+		}
+		return nil
+	},
+}
+
+func init() {
+	toolsUninstallCmd.Flags().BoolVar(&toolsUninstallLocalFlag, "local", false, "Remove from the project-local "+gotool.LocalBinDir+" instead of GOBIN")
+	toolsCmd.AddCommand(toolsUninstallCmd)
+}
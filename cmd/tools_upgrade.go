@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+	"github.com/thought2code/godev/internal/tui"
+)
+
+var toolsUpgradeAllFlag bool
+
+var toolsUpgradeCmdExample = strings.Trim(`
+  godev tools upgrade gofumpt
+  godev tools upgrade --all
+`, strconst.NewLine)
+
+var toolsUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [tool-name|tool-package-path]",
+	Short: "Upgrade Go tools to the newest version in the module cache",
+	Long: "Upgrade Go tools to the newest version in the module cache, resolved like the go command does\n" +
+		"with GOPROXY=file://$GOMODCACHE/cache/download, so no network access is needed",
+	Example: toolsUpgradeCmdExample,
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		if toolsUpgradeAllFlag == (len(args) == 1) {
+			return errors.New("specify either a tool or --all")
+		}
+
+		tools, err := projectTools()
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			tool, ok := findTool(tools, args[0])
+			if !ok {
+				tool = config.ToolConfig{Name: gobuild.BinaryName(args[0], strconst.Empty), Package: args[0]}
+			}
+			tools = []config.ToolConfig{tool}
+		}
+
+		env, err := gotool.ReadEnv()
+		if err != nil {
+			return err
+		}
+		moduleTools, err := gotool.ReadModuleTools("go.mod")
+		if err != nil {
+			return err
+		}
+
+		var failed []string
+		for _, tool := range tools {
+			if err := upgradeTool(cmd.Context(), env, moduleTools, tool); err != nil {
+				fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to upgrade %s: %v", strconst.EmojiFailure, tool.Name, err)))
+				failed = append(failed, tool.Name)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to upgrade %s", strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+// upgradeTool updates the tool directive of go.mod for a module tool, and reinstalls the binary otherwise
+func upgradeTool(ctx context.Context, env gotool.Env, moduleTools []gotool.ModuleTool, tool config.ToolConfig) error {
+	current, modulePath, gobin := strconst.Empty, strconst.Empty, strconst.Empty
+	moduleTool, declared := gotool.Find(moduleTools, tool.Package)
+	if declared {
+		current, modulePath = moduleTool.Version, moduleTool.Module
+	} else if binary, err := gotool.LookBinary(env, tool.Name); err == nil {
+		current, modulePath = binary.Version, binary.Module
+		if binary.IsLocal() {
+			gobin = filepath.Dir(binary.Path)
+		}
+	}
+	if modulePath == strconst.Empty {
+		var ok bool
+		if modulePath, ok = gotool.CachedModule(env.GOMODCACHE, tool.Package); !ok {
+			return fmt.Errorf("no module providing %s in the module cache, run 'godev tools install %s@latest'", tool.Package, tool.Package)
+		}
+	}
+
+	latest, err := gotool.LatestCachedVersion(env.GOMODCACHE, modulePath)
+	if err != nil {
+		return err
+	}
+	if semver.IsValid(current) && semver.Compare(current, latest) >= 0 {
+		fmt.Println(tui.SuccessStyle(fmt.Sprintf("%s %s %s is up to date", strconst.EmojiSuccess, tool.Name, current)))
+		return nil
+	}
+
+	// the version is in the module cache, resolving it through the network proxy is unnecessary
+	localProxy := []string{"GOPROXY=" + env.LocalProxy()}
+	if declared {
+		fmt.Printf("🔧 Upgrading the tool directive of %s to %s...\n", tool.Package, latest)
+		return osutil.RunCommandContext(ctx, osutil.Command{
+			Name: "go",
+			Args: []string{"get", "-tool", fmt.Sprintf("%s@%s", tool.Package, latest)},
+			Env:  localProxy,
+		})
+	}
+	if gobin != strconst.Empty {
+		// keep the project-local binary where it is
+		localProxy = append(localProxy, "GOBIN="+gobin)
+	}
+	if err := installGoTools(ctx, localProxy, tool.Package, latest); err != nil {
+		return err
+	}
+	if tool.Version != latest && tool.Version != "latest" {
+		fmt.Println(tui.WarnStyle(fmt.Sprintf("%s Pin %s %s in the tools of %s, or doctor reports it as newer than %s",
+			strconst.EmojiTips, tool.Name, latest, config.FileName, tool.Version)))
+	}
+	return nil
+}
+
+func init() {
+	toolsUpgradeCmd.Flags().BoolVar(&toolsUpgradeAllFlag, "all", false, "Upgrade all the tools of the project")
+	toolsCmd.AddCommand(toolsUpgradeCmd)
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// FileName is the name of the project configuration file, discovered from the current
+// directory up to the module root
+const FileName = "godev.yaml"
+
+type Config struct {
+	Test   TestConfig   `yaml:"test"`
+	Lint   LintConfig   `yaml:"lint"`
+	Tools  []ToolConfig `yaml:"tools"`
+	Build  BuildConfig  `yaml:"build"`
+	Doctor DoctorConfig `yaml:"doctor"`
+
+	// Path is the loaded configuration file, empty when only the defaults are used
+	Path string `yaml:"-"`
+}
+
+type TestConfig struct {
+	CoverageDir string          `yaml:"coverage_dir"`
+	Integ       IntegTestConfig `yaml:"integ"`
+}
+
+type IntegTestConfig struct {
+	Tags    string            `yaml:"tags"`
+	Timeout string            `yaml:"timeout"`
+	Serial  bool              `yaml:"serial"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+type LintConfig struct {
+	Steps []string `yaml:"steps"`
+	// FailFast stops the pipeline at the first failed step instead of running all of them
+	FailFast bool          `yaml:"fail_fast"`
+	Commands []LintCommand `yaml:"commands,omitempty"`
+	// Baseline is the committed file of the accepted findings, only new findings fail the lint
+	Baseline string `yaml:"baseline"`
+}
+
+// LintCommand is a custom lint step, run when its name is listed in the steps
+type LintCommand struct {
+	Name string   `yaml:"name"`
+	Run  []string `yaml:"run"`
+	// Check is the command run by 'godev lint --check', the step is skipped there when empty
+	Check []string `yaml:"check,omitempty"`
+}
+
+type ToolConfig struct {
+	Name    string `yaml:"name"`
+	Package string `yaml:"package"`
+	Version string `yaml:"version"`
+
+	// Source tells where Version is pinned, one of the ToolSource constants
+	Source string `yaml:"-"`
+}
+
+// where the version of a tool comes from
+const (
+	ToolSourceDefault = "godev default"
+	ToolSourceConfig  = FileName
+	ToolSourceGoMod   = "go.mod"
+)
+
+type BuildConfig struct {
+	Platforms  []string      `yaml:"platforms"`
+	Output     string        `yaml:"output"`
+	TrimPath   bool          `yaml:"trimpath"`
+	CGO        bool          `yaml:"cgo"`
+	LDFlags    string        `yaml:"ldflags"`
+	VersionPkg string        `yaml:"version_pkg"`
+	Release    ReleaseConfig `yaml:"release"`
+}
+
+type ReleaseConfig struct {
+	Platforms []string `yaml:"platforms"`
+	Format    string   `yaml:"format"`
+	SBOM      bool     `yaml:"sbom"`
+	Changelog bool     `yaml:"changelog"`
+}
+
+type DoctorConfig struct {
+	Skip   []string      `yaml:"skip"`
+	Checks []CheckConfig `yaml:"checks,omitempty"`
+}
+
+// CheckConfig declares a project specific doctor check, the fields used depend on the type
+type CheckConfig struct {
+	Name   string `yaml:"name"`
+	Type   string `yaml:"type"`
+	Advice string `yaml:"advice,omitempty"`
+
+	// Severity of the failed check, error (default) fails 'godev doctor' while warning does not
+	Severity string `yaml:"severity,omitempty"`
+
+	// Fix is the command run by 'godev doctor --fix' when the check fails
+	Fix []string `yaml:"fix,omitempty"`
+
+	// binary: the executable must be on PATH, with a version >= MinVersion when set
+	Binary      string   `yaml:"binary,omitempty"`
+	MinVersion  string   `yaml:"min_version,omitempty"`
+	VersionArgs []string `yaml:"version_args,omitempty"`
+
+	// env: the environment variable must be set and not empty
+	Env string `yaml:"env,omitempty"`
+
+	// file: the file or directory must exist
+	File string `yaml:"file,omitempty"`
+
+	// command: the command must exit with 0
+	Command []string `yaml:"command,omitempty"`
+}
+
+// doctor check types declarable in godev.yaml
+const (
+	CheckTypeBinary  = "binary"
+	CheckTypeEnv     = "env"
+	CheckTypeFile    = "file"
+	CheckTypeCommand = "command"
+)
+
+var CheckTypes = []string{CheckTypeBinary, CheckTypeEnv, CheckTypeFile, CheckTypeCommand}
+
+// lint steps in the order they run by default
+const (
+	LintStepGoimports    = "goimports"
+	LintStepGofumpt      = "gofumpt"
+	LintStepGolangciLint = "golangci-lint"
+	LintStepTidy         = "tidy"
+)
+
+var LintSteps = []string{LintStepGoimports, LintStepGofumpt, LintStepGolangciLint, LintStepTidy}
+
+func Default() *Config {
+	return &Config{
+		Test: TestConfig{
+			CoverageDir: "coverage",
+			Integ: IntegTestConfig{
+				Tags:    "integration",
+				Timeout: "30m",
+			},
+		},
+		Lint: LintConfig{
+			Steps:    append([]string(nil), LintSteps...),
+			Baseline: "lint-baseline.json",
+		},
+		Tools: DefaultTools(),
+		Build: BuildConfig{
+			Output:     "dist",
+			TrimPath:   true,
+			VersionPkg: "main",
+			Release: ReleaseConfig{
+				Platforms: []string{"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64"},
+				Format:    "auto",
+				SBOM:      true,
+				Changelog: true,
+			},
+		},
+	}
+}
+
+// DefaultTools returns the tools recommended by godev
+func DefaultTools() []ToolConfig {
+	return []ToolConfig{
+		{Name: "gofumpt", Package: strconst.Gofumpt, Version: strconst.RecommendedGofumptVersion, Source: ToolSourceDefault},
+		{Name: "goimports", Package: strconst.Goimports, Version: strconst.RecommendedGoimportsVersion, Source: ToolSourceDefault},
+		{Name: "golangci-lint", Package: strconst.GolangciLint, Version: strconst.RecommendedGolangciLintVersion, Source: ToolSourceDefault},
+	}
+}
+
+// Tool finds the configured tool by its binary name
+func (c *Config) Tool(name string) (ToolConfig, bool) {
+	for _, tool := range c.Tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return ToolConfig{}, false
+}
+
+// mergeTools overrides the fields of the default tools with the configured ones by name
+// and appends the tools godev does not know about
+func mergeTools(defaults, configured []ToolConfig) []ToolConfig {
+	merged := append([]ToolConfig(nil), defaults...)
+	for _, tool := range configured {
+		i := 0
+		for ; i < len(merged) && merged[i].Name != tool.Name; i++ {
+		}
+		if i == len(merged) {
+			tool.Source = ToolSourceConfig
+			merged = append(merged, tool)
+			continue
+		}
+		if tool.Package != strconst.Empty {
+			merged[i].Package = tool.Package
+		}
+		if tool.Version != strconst.Empty {
+			merged[i].Version = tool.Version
+			merged[i].Source = ToolSourceConfig
+		}
+	}
+	return merged
+}
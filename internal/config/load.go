@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Issue is a problem found in the configuration file, Line is 0 when unknown
+type Issue struct {
+	Line    int
+	Message string
+}
+
+type ValidationError struct {
+	Path   string
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Line > 0 {
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", e.Path, issue.Line, issue.Message))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %s", e.Path, issue.Message))
+		}
+	}
+	return strings.Join(lines, strconst.NewLine)
+}
+
+// Find walks up from dir to the module root (the first directory containing go.mod) and
+// returns the path of the configuration file, or an empty string when there is none
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return strconst.Empty, err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if exist, err := osutil.CheckExist(path); err != nil {
+			return strconst.Empty, err
+		} else if exist {
+			return path, nil
+		}
+
+		if exist, err := osutil.CheckExist(filepath.Join(dir, "go.mod")); err != nil {
+			return strconst.Empty, err
+		} else if exist {
+			return strconst.Empty, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return strconst.Empty, nil
+		}
+		dir = parent
+	}
+}
+
+// Load discovers the configuration file from the current directory and merges it with the defaults
+func Load() (*Config, error) {
+	path, err := Find(".")
+	if err != nil {
+		return nil, err
+	}
+	if path == strconst.Empty {
+		return Default(), nil
+	}
+	return LoadFile(path)
+}
+
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			validationErr.Path = path
+		}
+		return nil, err
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+// Parse merges the YAML configuration onto the defaults and validates the result,
+// problems are reported as a *ValidationError with line numbers
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, &ValidationError{Path: FileName, Issues: yamlIssues(err)}
+	}
+
+	// the configured tools are merged with the defaults by name below
+	cfg.Tools = nil
+
+	// type errors do not stop the decoding, so keep validating the rest of the file
+	var issues []Issue
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, &ValidationError{Path: FileName, Issues: yamlIssues(err)}
+		}
+		issues = yamlIssues(err)
+	}
+	cfg.Tools = mergeTools(DefaultTools(), cfg.Tools)
+
+	issues = append(issues, validate(cfg, &root)...)
+	if len(issues) > 0 {
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+		return nil, &ValidationError{Path: FileName, Issues: issues}
+	}
+	return cfg, nil
+}
+
+func Marshal(cfg *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var yamlLinePrefix = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlIssues extracts the line numbers from the syntax and type errors of the yaml package
+func yamlIssues(err error) []Issue {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	issues := make([]Issue, 0, len(messages))
+	for _, message := range messages {
+		if match := yamlLinePrefix.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			issues = append(issues, Issue{Line: line, Message: match[2]})
+			continue
+		}
+		issues = append(issues, Issue{Message: strings.TrimPrefix(message, "yaml: ")})
+	}
+	return issues
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+func TestFind(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+
+	moduleDir := filepath.Join(tempDir, "module")
+	nestedDir := filepath.Join(moduleDir, "internal", "pkg")
+	if err := os.MkdirAll(nestedDir, 0o755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module example.com/m\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		dir     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "no config up to the module root",
+			setup:   func() {},
+			dir:     nestedDir,
+			want:    strconst.Empty,
+			wantErr: false,
+		},
+		{
+			name: "config above the module root is ignored",
+			setup: func() {
+				if err := os.WriteFile(filepath.Join(tempDir, FileName), []byte{}, 0o644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			},
+			dir:     nestedDir,
+			want:    strconst.Empty,
+			wantErr: false,
+		},
+		{
+			name: "config at the module root",
+			setup: func() {
+				if err := os.WriteFile(filepath.Join(moduleDir, FileName), []byte{}, 0o644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			},
+			dir:     nestedDir,
+			want:    filepath.Join(moduleDir, FileName),
+			wantErr: false,
+		},
+		{
+			name: "nearest config wins",
+			setup: func() {
+				if err := os.WriteFile(filepath.Join(nestedDir, FileName), []byte{}, 0o644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			},
+			dir:     nestedDir,
+			want:    filepath.Join(nestedDir, FileName),
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, gotErr := Find(tt.dir)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Find() failed, got unexpected error = %v", gotErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Find() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := []byte(`
+test:
+  coverage_dir: cov
+  integ:
+    env:
+      DB: postgres
+lint:
+  steps: [gofumpt, golangci-lint, vet]
+  fail_fast: true
+  commands:
+    - name: vet
+      run: [go, vet, ./...]
+tools:
+  - name: gofumpt
+    version: v0.8.0
+  - name: mockgen
+    package: go.uber.org/mock/mockgen
+    version: v0.5.0
+`)
+
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() failed, got unexpected error = %v", err)
+	}
+
+	want := Default()
+	want.Test.CoverageDir = "cov"
+	want.Test.Integ.Env = map[string]string{"DB": "postgres"}
+	want.Lint.Steps = []string{LintStepGofumpt, LintStepGolangciLint, "vet"}
+	want.Lint.FailFast = true
+	want.Lint.Commands = []LintCommand{{Name: "vet", Run: []string{"go", "vet", "./..."}}}
+	want.Tools[0].Version = "v0.8.0"
+	want.Tools[0].Source = ToolSourceConfig
+	want.Tools = append(want.Tools, ToolConfig{Name: "mockgen", Package: "go.uber.org/mock/mockgen", Version: "v0.5.0", Source: ToolSourceConfig})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() failed, got = %+v, want = %+v", got, want)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	got, err := Parse([]byte{})
+	if err != nil {
+		t.Fatalf("Parse() failed, got unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(got, Default()) {
+		t.Errorf("Parse() failed, got = %+v, want the defaults", got)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Issue
+	}{
+		{
+			name: "syntax error",
+			data: "test:\n  coverage_dir: [\n",
+			want: []Issue{{Line: 2, Message: "did not find expected node content"}},
+		},
+		{
+			name: "unknown field",
+			data: "lint:\n  step: [gofumpt]\n",
+			want: []Issue{{Line: 2, Message: "field step not found in type config.LintConfig"}},
+		},
+		{
+			name: "invalid values",
+			data: "test:\n  integ:\n    timeout: soon\nlint:\n  steps:\n    - gofumpt\n    - vet\nbuild:\n  release:\n    format: rar\n",
+			want: []Issue{
+				{Line: 3, Message: `test.integ.timeout "soon" is not a valid duration, e.g. 30m`},
+				{Line: 7, Message: `lint.steps has unknown step "vet", expected one of [goimports gofumpt golangci-lint tidy]`},
+				{Line: 10, Message: `build.release.format "rar" is invalid, expected one of [auto tar.gz zip]`},
+			},
+		},
+		{
+			name: "invalid lint commands",
+			data: "lint:\n  steps: [tidy, vet]\n  commands:\n    - name: tidy\n      run: [go, mod, tidy]\n    - name: vet\n",
+			want: []Issue{
+				{Line: 4, Message: `lint command "tidy" is already a lint step`},
+				{Line: 6, Message: `lint command "vet" must set run`},
+			},
+		},
+		{
+			name: "invalid doctor checks",
+			data: "doctor:\n  checks:\n    - name: protoc\n      type: binary\n      min_version: latest\n    - name: db\n      type: database\n",
+			want: []Issue{
+				{Line: 3, Message: `doctor check "protoc" of type binary must set binary`},
+				{Line: 5, Message: `doctor check "protoc" has invalid min_version "latest", e.g. 1.2.3`},
+				{Line: 7, Message: `doctor check "db" has unknown type "database", expected one of [binary env file command]`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Parse() failed, got = %v, want a validation error", err)
+			}
+			if !reflect.DeepEqual(validationErr.Issues, tt.want) {
+				t.Errorf("Parse() failed, got = %+v, want = %+v", validationErr.Issues, tt.want)
+			}
+		})
+	}
+}
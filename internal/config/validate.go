@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+var releaseFormats = []string{"auto", "tar.gz", "zip"}
+
+func validate(cfg *Config, root *yaml.Node) []Issue {
+	var issues []Issue
+	report := func(message string, path ...any) {
+		issues = append(issues, Issue{Line: lineOf(root, path...), Message: message})
+	}
+
+	if cfg.Test.CoverageDir == strconst.Empty {
+		report("test.coverage_dir must not be empty", "test", "coverage_dir")
+	}
+	if _, err := time.ParseDuration(cfg.Test.Integ.Timeout); err != nil {
+		report(fmt.Sprintf("test.integ.timeout %q is not a valid duration, e.g. 30m", cfg.Test.Integ.Timeout), "test", "integ", "timeout")
+	}
+
+	if cfg.Lint.Baseline == strconst.Empty {
+		report("lint.baseline must not be empty", "lint", "baseline")
+	}
+
+	lintSteps := append([]string(nil), LintSteps...)
+	for i, command := range cfg.Lint.Commands {
+		switch {
+		case command.Name == strconst.Empty:
+			report("lint.commands entry must have a name", "lint", "commands", i)
+		case slices.Contains(lintSteps, command.Name):
+			report(fmt.Sprintf("lint command %q is already a lint step", command.Name), "lint", "commands", i, "name")
+		default:
+			lintSteps = append(lintSteps, command.Name)
+		}
+		if len(command.Run) == 0 {
+			report(fmt.Sprintf("lint command %q must set run", command.Name), "lint", "commands", i)
+		}
+	}
+	for i, step := range cfg.Lint.Steps {
+		if !slices.Contains(lintSteps, step) {
+			report(fmt.Sprintf("lint.steps has unknown step %q, expected one of %v", step, lintSteps), "lint", "steps", i)
+		} else if slices.Index(cfg.Lint.Steps, step) != i {
+			report(fmt.Sprintf("lint.steps has duplicated step %q", step), "lint", "steps", i)
+		}
+	}
+
+	// the tools are merged with the defaults, so locate the entries in the file by name
+	for _, tool := range cfg.Tools {
+		i := toolIndex(root, tool.Name)
+		if tool.Name == strconst.Empty {
+			report("tools entry must have a name", "tools", i)
+		}
+		if tool.Package == strconst.Empty {
+			report(fmt.Sprintf("tool %q must have a package", tool.Name), "tools", i)
+		}
+		if tool.Version == strconst.Empty {
+			report(fmt.Sprintf("tool %q must have a version, e.g. latest or v1.2.3", tool.Name), "tools", i)
+		}
+	}
+
+	for i, platform := range cfg.Build.Platforms {
+		if _, err := gobuild.ParsePlatforms([]string{platform}); err != nil {
+			report("build.platforms: "+err.Error(), "build", "platforms", i)
+		}
+	}
+	for i, platform := range cfg.Build.Release.Platforms {
+		if _, err := gobuild.ParsePlatforms([]string{platform}); err != nil {
+			report("build.release.platforms: "+err.Error(), "build", "release", "platforms", i)
+		}
+	}
+	if cfg.Build.Output == strconst.Empty {
+		report("build.output must not be empty", "build", "output")
+	}
+	if cfg.Build.VersionPkg == strconst.Empty {
+		report("build.version_pkg must not be empty", "build", "version_pkg")
+	}
+	if !slices.Contains(releaseFormats, cfg.Build.Release.Format) {
+		report(fmt.Sprintf("build.release.format %q is invalid, expected one of %v", cfg.Build.Release.Format, releaseFormats), "build", "release", "format")
+	}
+
+	for i, check := range cfg.Doctor.Checks {
+		if check.Name == strconst.Empty {
+			report("doctor.checks entry must have a name", "doctor", "checks", i)
+		} else if slices.IndexFunc(cfg.Doctor.Checks, func(c CheckConfig) bool { return c.Name == check.Name }) != i {
+			report(fmt.Sprintf("doctor check %q is declared more than once", check.Name), "doctor", "checks", i, "name")
+		}
+
+		if check.Severity != strconst.Empty && check.Severity != "error" && check.Severity != "warning" {
+			report(fmt.Sprintf("doctor check %q has invalid severity %q, expected error or warning", check.Name, check.Severity), "doctor", "checks", i, "severity")
+		}
+
+		missing := strconst.Empty
+		switch check.Type {
+		case CheckTypeBinary:
+			if check.Binary == strconst.Empty {
+				missing = "binary"
+			}
+			if check.MinVersion != strconst.Empty && !semver.IsValid(CanonicalVersion(check.MinVersion)) {
+				report(fmt.Sprintf("doctor check %q has invalid min_version %q, e.g. 1.2.3", check.Name, check.MinVersion), "doctor", "checks", i, "min_version")
+			}
+		case CheckTypeEnv:
+			if check.Env == strconst.Empty {
+				missing = "env"
+			}
+		case CheckTypeFile:
+			if check.File == strconst.Empty {
+				missing = "file"
+			}
+		case CheckTypeCommand:
+			if len(check.Command) == 0 {
+				missing = "command"
+			}
+		default:
+			report(fmt.Sprintf("doctor check %q has unknown type %q, expected one of %v", check.Name, check.Type, CheckTypes), "doctor", "checks", i, "type")
+		}
+		if missing != strconst.Empty {
+			report(fmt.Sprintf("doctor check %q of type %s must set %s", check.Name, check.Type, missing), "doctor", "checks", i)
+		}
+	}
+
+	return issues
+}
+
+// CanonicalVersion prefixes the version with v as expected by golang.org/x/mod/semver
+func CanonicalVersion(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+// toolIndex finds the index of the named tool in the tools sequence of the file, -1 when absent
+func toolIndex(root *yaml.Node, name string) int {
+	tools, _ := nodeAt(root, "tools")
+	if tools == nil || tools.Kind != yaml.SequenceNode {
+		return -1
+	}
+	for i := range tools.Content {
+		if node, _ := nodeAt(root, "tools", i, "name"); node != nil && node.Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// lineOf returns the line of the node at path, falling back to the line of the deepest existing parent
+func lineOf(root *yaml.Node, path ...any) int {
+	node, parent := nodeAt(root, path...)
+	if node != nil {
+		return node.Line
+	}
+	if parent != nil {
+		return parent.Line
+	}
+	return 0
+}
+
+// nodeAt walks the path made of mapping keys and sequence indexes, returning the node found
+// or nil with the deepest existing parent
+func nodeAt(root *yaml.Node, path ...any) (node, parent *yaml.Node) {
+	if root == nil || len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	node = root.Content[0]
+	for _, elem := range path {
+		var next *yaml.Node
+		switch key := elem.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == key {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && key >= 0 && key < len(node.Content) {
+				next = node.Content[key]
+			}
+		}
+		if next == nil {
+			return nil, node
+		}
+		node = next
+	}
+	return node, nil
+}
@@ -0,0 +1,223 @@
+package doctor
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Builtin returns the checks godev always runs, cfgErr is the error of loading godev.yaml
+// and installTool is used to fix missing tools
+func Builtin(cfg *config.Config, cfgErr error, installTool func(config.ToolConfig) error) []Check {
+	checks := []Check{
+		NewCheck("godev configuration", "The godev.yaml file is valid", func() *Result { return checkConfig(cfgErr) }),
+		NewCheck("Go module file", "The go.mod file exists in the current directory", checkGoModuleFile),
+		NewCheck("Go version", "The installed Go satisfies the go directive of go.mod", checkGoVersion),
+	}
+	for _, tool := range cfg.Tools {
+		checks = append(checks, NewCheck(
+			"Go tools "+tool.Name,
+			fmt.Sprintf("%s (%s) is installed at the pinned version", tool.Name, tool.Package),
+			func() *Result { return checkGoToolsVersion(tool, installTool) }))
+	}
+	return checks
+}
+
+func checkConfig(err error) *Result {
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: strings.ReplaceAll(err.Error(), strconst.NewLine, "; "),
+			Advice:  fmt.Sprintf("Fix the %s file, run 'godev config validate' for details", config.FileName),
+		}
+	}
+
+	return &Result{
+		Passed:  true,
+		Message: "The configuration is valid",
+		Advice:  strconst.Empty,
+	}
+}
+
+func checkGoModuleFile() *Result {
+	exist, err := osutil.CheckExist("go.mod")
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: err.Error(),
+			Advice:  "Check if the go.mod file exists and readable",
+		}
+	}
+	if !exist {
+		return &Result{
+			Passed:  false,
+			Message: "The go.mod file does not exist",
+			Advice:  "Create the go.mod file using 'go mod init'",
+			Fix:     goModInitFix(),
+		}
+	}
+
+	return &Result{
+		Passed:  true,
+		Message: "The go.mod file exists",
+		Advice:  strconst.Empty,
+	}
+}
+
+func checkGoVersion() *Result {
+	checkGoModuleFileResult := checkGoModuleFile()
+	if !checkGoModuleFileResult.Passed {
+		return &Result{
+			Passed:  false,
+			Message: "No go.mod file found, unable to check required Go version",
+			Advice:  "Create the go.mod file using 'go mod init'",
+		}
+	}
+
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: err.Error(),
+			Advice:  "Check if the go.mod file exists and readable",
+		}
+	}
+
+	mod, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: err.Error(),
+			Advice:  "Check if the go.mod file is a valid Go module file",
+		}
+	}
+
+	if mod.Go == nil {
+		return &Result{
+			Passed:  false,
+			Message: "The Go version directive is missing in the go.mod file",
+			Advice:  "Add the Go version directive to the go.mod file",
+			Fix:     addGoDirectiveFix(mod),
+		}
+	}
+
+	installedGoVersion, err := localGoVersion()
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: err.Error(),
+			Advice:  "Install Go and make sure the go command is on PATH, download from https://golang.org/dl/",
+		}
+	}
+
+	toolchain := strconst.Empty
+	if mod.Toolchain != nil {
+		toolchain = mod.Toolchain.Name
+	}
+	return evaluateGoVersion(installedGoVersion, mod.Go.Version, toolchain, goToolchainSetting())
+}
+
+func checkGoToolsVersion(tool config.ToolConfig, installTool func(config.ToolConfig) error) *Result {
+	reinstallFix := &Fix{
+		Description: fmt.Sprintf("Install %s@%s", tool.Package, tool.Version),
+		Apply:       func() error { return installTool(tool) },
+	}
+
+	// the go command builds the tools of the module on demand at the version of go.mod
+	if tool.Source == config.ToolSourceGoMod {
+		return &Result{
+			Passed:  true,
+			Message: fmt.Sprintf("declared in go.mod at %s, runs with 'go tool %s'", tool.Version, tool.Name),
+			Advice:  strconst.Empty,
+		}
+	}
+
+	// a missing tool fails the check, only a drift from the pinned version is a warning
+	path, err := gotool.LookPath(tool.Name)
+	if err != nil {
+		return &Result{
+			Passed:   false,
+			Message:  "Not installed",
+			Advice:   fmt.Sprintf("Run 'godev tools install' to install %s version: %s", tool.Name, tool.Version),
+			Severity: SeverityError,
+			Fix:      reinstallFix,
+		}
+	}
+
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return &Result{
+			Passed:   false,
+			Message:  fmt.Sprintf("Unable to read the build information of %s: %v", path, err),
+			Advice:   fmt.Sprintf("Run 'godev tools install' to reinstall %s version: %s", tool.Name, tool.Version),
+			Severity: SeverityError,
+			Fix:      reinstallFix,
+		}
+	}
+
+	result := evaluateToolVersion(tool, info.Main.Version, info.GoVersion, moduleGoDirective())
+	if !result.Passed {
+		result.Fix = reinstallFix
+	}
+	return result
+}
+
+// moduleGoDirective returns the go directive of ./go.mod, empty if there is none
+func moduleGoDirective() string {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return strconst.Empty
+	}
+	mod, err := modfile.Parse("go.mod", data, nil)
+	if err != nil || mod.Go == nil {
+		return strconst.Empty
+	}
+	return mod.Go.Version
+}
+
+// goModInitFix initializes the module named after the current directory
+func goModInitFix() *Fix {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	module := filepath.Base(dir)
+
+	return &Fix{
+		Description: fmt.Sprintf("Run 'go mod init %s'", module),
+		Apply:       func() error { return osutil.RunCommand("go", "mod", "init", module) },
+	}
+}
+
+// addGoDirectiveFix adds the go directive of the installed Go version to go.mod
+func addGoDirectiveFix(mod *modfile.File) *Fix {
+	goVersion := strings.TrimPrefix(runtime.Version(), "go")
+	if output, err := localGoVersion(); err == nil && version.IsValid(output) {
+		goVersion = strings.TrimPrefix(output, "go")
+	}
+
+	return &Fix{
+		Description: fmt.Sprintf("Add 'go %s' to go.mod", goVersion),
+		Apply: func() error {
+			if err := mod.AddGoStmt(goVersion); err != nil {
+				return err
+			}
+			data, err := mod.Format()
+			if err != nil {
+				return err
+			}
+			return osutil.WriteFile("go.mod", data, 0o644)
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package doctor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Result struct {
+	Passed  bool
+	Message string
+	Advice  string
+
+	// Severity of a failed check, defaults to SeverityError when empty
+	Severity Severity
+
+	// Fix remediates the failed check automatically, nil when it has to be done by hand
+	Fix *Fix
+}
+
+type Fix struct {
+	Description string
+	Apply       func() error
+}
+
+// Check is a single diagnosis of the development environment
+type Check interface {
+	Name() string
+	Description() string
+	Run() *Result
+}
+
+// SourceBuiltin is the source of the checks shipped with godev
+const SourceBuiltin = "built-in"
+
+type funcCheck struct {
+	name        string
+	description string
+	source      string
+	run         func() *Result
+}
+
+func (c *funcCheck) Name() string        { return c.name }
+func (c *funcCheck) Description() string { return c.description }
+func (c *funcCheck) Source() string      { return c.source }
+func (c *funcCheck) Run() *Result        { return c.run() }
+
+// NewCheck adapts a function into a built-in Check
+func NewCheck(name, description string, run func() *Result) Check {
+	return &funcCheck{name: name, description: description, source: SourceBuiltin, run: run}
+}
+
+// SourceOf tells where the check comes from, checks may implement Source() string to override it
+func SourceOf(check Check) string {
+	if s, ok := check.(interface{ Source() string }); ok {
+		return s.Source()
+	}
+	return SourceBuiltin
+}
+
+// Registry holds the checks in registration order, names are unique
+type Registry struct {
+	checks []Check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) Register(checks ...Check) error {
+	for _, check := range checks {
+		if _, ok := r.Get(check.Name()); ok {
+			return fmt.Errorf("doctor check %q is already registered", check.Name())
+		}
+		r.checks = append(r.checks, check)
+	}
+	return nil
+}
+
+func (r *Registry) Get(name string) (Check, bool) {
+	for _, check := range r.checks {
+		if check.Name() == name {
+			return check, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) Checks() []Check {
+	return slices.Clone(r.checks)
+}
+
+// Filter keeps the checks named in only (all when empty) minus the ones named in skip,
+// unknown names are reported as an error to catch typos
+func (r *Registry) Filter(only, skip []string) ([]Check, error) {
+	var unknown []string
+	for _, name := range append(slices.Clone(only), skip...) {
+		if _, ok := r.Get(name); !ok {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown doctor check %s, run 'godev doctor --list' to see all checks", strings.Join(unknown, ", "))
+	}
+
+	var checks []Check
+	for _, check := range r.checks {
+		if len(only) > 0 && !slices.Contains(only, check.Name()) {
+			continue
+		}
+		if slices.Contains(skip, check.Name()) {
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
@@ -0,0 +1,65 @@
+package doctor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestRegistry(t *testing.T, names ...string) *Registry {
+	registry := NewRegistry()
+	for _, name := range names {
+		if err := registry.Register(NewCheck(name, name, func() *Result { return &Result{Passed: true} })); err != nil {
+			t.Fatalf("Failed to register check: %v", err)
+		}
+	}
+	return registry
+}
+
+func checkNames(checks []Check) []string {
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.Name())
+	}
+	return names
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := newTestRegistry(t, "a", "b")
+	if err := registry.Register(NewCheck("a", "duplicated", nil)); err == nil {
+		t.Errorf("Register() failed, want error for duplicated check name")
+	}
+	if got := checkNames(registry.Checks()); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Checks() failed, got = %v, want = %v", got, []string{"a", "b"})
+	}
+}
+
+func TestRegistryFilter(t *testing.T) {
+	registry := newTestRegistry(t, "a", "b", "c")
+
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "no filter", only: nil, skip: nil, want: []string{"a", "b", "c"}, wantErr: false},
+		{name: "only keeps registration order", only: []string{"c", "a"}, skip: nil, want: []string{"a", "c"}, wantErr: false},
+		{name: "skip", only: nil, skip: []string{"b"}, want: []string{"a", "c"}, wantErr: false},
+		{name: "skip wins over only", only: []string{"a", "b"}, skip: []string{"a"}, want: []string{"b"}, wantErr: false},
+		{name: "unknown check", only: []string{"d"}, skip: nil, want: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := registry.Filter(tt.only, tt.skip)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Filter() failed, got unexpected error = %v", gotErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(checkNames(got), tt.want) {
+				t.Errorf("Filter() failed, got = %v, want = %v", checkNames(got), tt.want)
+			}
+		})
+	}
+}
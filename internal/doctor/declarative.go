@@ -0,0 +1,214 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gotool"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// FromConfig turns the checks declared in godev.yaml into runnable checks
+func FromConfig(checks []config.CheckConfig) []Check {
+	result := make([]Check, 0, len(checks))
+	for _, c := range checks {
+		result = append(result, &funcCheck{
+			name:        c.Name,
+			description: describeDeclared(c),
+			source:      config.FileName,
+			run:         func() *Result { return runDeclared(c) },
+		})
+	}
+	return result
+}
+
+func describeDeclared(c config.CheckConfig) string {
+	switch c.Type {
+	case config.CheckTypeBinary:
+		if c.MinVersion != strconst.Empty {
+			return fmt.Sprintf("%s is on PATH with version >= %s", c.Binary, c.MinVersion)
+		}
+		return fmt.Sprintf("%s is on PATH", c.Binary)
+	case config.CheckTypeEnv:
+		return fmt.Sprintf("Environment variable %s is set", c.Env)
+	case config.CheckTypeFile:
+		return fmt.Sprintf("%s exists", c.File)
+	case config.CheckTypeCommand:
+		return fmt.Sprintf("'%s' exits with 0", strings.Join(c.Command, strconst.Space))
+	default:
+		return fmt.Sprintf("Unknown check type %q", c.Type)
+	}
+}
+
+func runDeclared(c config.CheckConfig) *Result {
+	var result *Result
+	switch c.Type {
+	case config.CheckTypeBinary:
+		result = checkBinary(c)
+	case config.CheckTypeEnv:
+		result = checkEnv(c)
+	case config.CheckTypeFile:
+		result = checkFile(c)
+	case config.CheckTypeCommand:
+		result = checkCommand(c)
+	default:
+		result = &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("Unknown check type %q", c.Type),
+			Advice:  fmt.Sprintf("Use one of %v as the check type in %s", config.CheckTypes, config.FileName),
+		}
+	}
+
+	if !result.Passed && c.Advice != strconst.Empty {
+		result.Advice = c.Advice
+	}
+	if c.Severity != strconst.Empty {
+		result.Severity = Severity(c.Severity)
+	}
+	if !result.Passed && len(c.Fix) > 0 {
+		result.Fix = &Fix{
+			Description: fmt.Sprintf("Run '%s'", strings.Join(c.Fix, strconst.Space)),
+			Apply:       func() error { return osutil.RunCommand(c.Fix[0], c.Fix[1:]...) },
+		}
+	}
+	return result
+}
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?`)
+
+// ExtractVersion finds the first version number like 1.2 or 1.2.3 in the output of a --version flag
+func ExtractVersion(output string) string {
+	return versionPattern.FindString(output)
+}
+
+func checkBinary(c config.CheckConfig) *Result {
+	path, err := gotool.LookPath(c.Binary)
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("%s is not found on PATH", c.Binary),
+			Advice:  fmt.Sprintf("Install %s and make sure it is on PATH", c.Binary),
+		}
+	}
+	if c.MinVersion == strconst.Empty {
+		return &Result{Passed: true, Message: path}
+	}
+
+	args := c.VersionArgs
+	if len(args) == 0 {
+		args = []string{"--version"}
+	}
+	if result, ok := skipInDryRun(path, args...); ok {
+		return result
+	}
+	output, err := combinedOutput(path, args...)
+	version := ExtractVersion(output)
+	if version == strconst.Empty {
+		message := fmt.Sprintf("Unable to detect the version from '%s %s'", c.Binary, strings.Join(args, strconst.Space))
+		if err != nil {
+			message += ": " + err.Error()
+		}
+		return &Result{
+			Passed:  false,
+			Message: message,
+			Advice:  "Set version_args of the check to the flags printing the version",
+		}
+	}
+
+	if semver.Compare(config.CanonicalVersion(version), config.CanonicalVersion(c.MinVersion)) < 0 {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("installed: %s, required: >= %s", version, c.MinVersion),
+			Advice:  fmt.Sprintf("Upgrade %s to version %s or higher", c.Binary, c.MinVersion),
+		}
+	}
+	return &Result{
+		Passed:  true,
+		Message: fmt.Sprintf("installed: %s, required: >= %s", version, c.MinVersion),
+	}
+}
+
+func checkEnv(c config.CheckConfig) *Result {
+	if os.Getenv(c.Env) == strconst.Empty {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("%s is not set", c.Env),
+			Advice:  fmt.Sprintf("Export the %s environment variable", c.Env),
+		}
+	}
+	return &Result{Passed: true, Message: fmt.Sprintf("%s is set", c.Env)}
+}
+
+func checkFile(c config.CheckConfig) *Result {
+	exist, err := osutil.CheckExist(c.File)
+	if err != nil {
+		return &Result{
+			Passed:  false,
+			Message: err.Error(),
+			Advice:  fmt.Sprintf("Check if %s is readable", c.File),
+		}
+	}
+	if !exist {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("%s does not exist", c.File),
+			Advice:  fmt.Sprintf("Create %s", c.File),
+		}
+	}
+	return &Result{Passed: true, Message: fmt.Sprintf("%s exists", c.File)}
+}
+
+func checkCommand(c config.CheckConfig) *Result {
+	if result, ok := skipInDryRun(c.Command[0], c.Command[1:]...); ok {
+		return result
+	}
+	output, err := combinedOutput(c.Command[0], c.Command[1:]...)
+	if err != nil {
+		message := err.Error()
+		if lines := strings.Split(strings.TrimSpace(output), strconst.NewLine); lines[len(lines)-1] != strconst.Empty {
+			message += ": " + lines[len(lines)-1]
+		}
+		return &Result{
+			Passed:  false,
+			Message: message,
+			Advice:  fmt.Sprintf("Make sure '%s' succeeds", strings.Join(c.Command, strconst.Space)),
+		}
+	}
+	return &Result{Passed: true, Message: "exited with 0"}
+}
+
+// declaredCommandTimeout keeps a hanging declared command from blocking the doctor
+const declaredCommandTimeout = time.Minute
+
+// combinedOutput runs a command declared in godev.yaml, which is not known to be read-only,
+// so it only runs outside of dry-run mode
+func combinedOutput(name string, args ...string) (string, error) {
+	var output bytes.Buffer
+	_, err := osutil.Run(context.Background(), osutil.Command{
+		Name:    name,
+		Args:    args,
+		Timeout: declaredCommandTimeout,
+		Stdout:  &output,
+		Stderr:  &output,
+	})
+	return output.String(), err
+}
+
+// skipInDryRun plans the declared command instead of running it in dry-run mode, the check
+// passing as its outcome is unknown
+func skipInDryRun(name string, args ...string) (*Result, bool) {
+	if !osutil.DryRun() {
+		return nil, false
+	}
+	_, _ = combinedOutput(name, args...)
+	return &Result{Passed: true, Message: "skipped in dry-run mode"}, true
+}
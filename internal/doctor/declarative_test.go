@@ -0,0 +1,161 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/osutil"
+)
+
+func TestExtractVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "git", output: "git version 2.39.5", want: "2.39.5"},
+		{name: "protoc", output: "libprotoc 3.21", want: "3.21"},
+		{name: "no version", output: "command not found", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractVersion(tt.output); got != tt.want {
+				t.Errorf("ExtractVersion() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+
+	existingFile := filepath.Join(tempDir, "exists.txt")
+	if err := os.WriteFile(existingFile, []byte("test"), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	t.Setenv("GODEV_DOCTOR_TEST", "1")
+
+	tests := []struct {
+		name       string
+		check      config.CheckConfig
+		wantPassed bool
+		wantAdvice string
+	}{
+		{
+			name:       "env set",
+			check:      config.CheckConfig{Name: "env", Type: config.CheckTypeEnv, Env: "GODEV_DOCTOR_TEST"},
+			wantPassed: true,
+		},
+		{
+			name:       "env not set uses custom advice",
+			check:      config.CheckConfig{Name: "env", Type: config.CheckTypeEnv, Env: "GODEV_DOCTOR_TEST_UNSET", Advice: "source .env"},
+			wantPassed: false,
+			wantAdvice: "source .env",
+		},
+		{
+			name:       "file exists",
+			check:      config.CheckConfig{Name: "file", Type: config.CheckTypeFile, File: existingFile},
+			wantPassed: true,
+		},
+		{
+			name:       "file does not exist",
+			check:      config.CheckConfig{Name: "file", Type: config.CheckTypeFile, File: filepath.Join(tempDir, "missing.txt")},
+			wantPassed: false,
+			wantAdvice: "Create " + filepath.Join(tempDir, "missing.txt"),
+		},
+		{
+			name:       "command succeeds",
+			check:      config.CheckConfig{Name: "command", Type: config.CheckTypeCommand, Command: []string{"go", "version"}},
+			wantPassed: true,
+		},
+		{
+			name:       "binary with satisfied min version",
+			check:      config.CheckConfig{Name: "binary", Type: config.CheckTypeBinary, Binary: "go", MinVersion: "1.0", VersionArgs: []string{"version"}},
+			wantPassed: true,
+		},
+		{
+			name:       "binary with too old version",
+			check:      config.CheckConfig{Name: "binary", Type: config.CheckTypeBinary, Binary: "go", MinVersion: "999.0", VersionArgs: []string{"version"}},
+			wantPassed: false,
+			wantAdvice: "Upgrade go to version 999.0 or higher",
+		},
+		{
+			name:       "binary not on PATH",
+			check:      config.CheckConfig{Name: "binary", Type: config.CheckTypeBinary, Binary: "this-binary-does-not-exist"},
+			wantPassed: false,
+			wantAdvice: "Install this-binary-does-not-exist and make sure it is on PATH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := FromConfig([]config.CheckConfig{tt.check})
+			if got := SourceOf(checks[0]); got != config.FileName {
+				t.Errorf("SourceOf() failed, got = %v, want = %v", got, config.FileName)
+			}
+
+			result := checks[0].Run()
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Run() failed, got passed = %v (%s), want = %v", result.Passed, result.Message, tt.wantPassed)
+			}
+			if !tt.wantPassed && result.Advice != tt.wantAdvice {
+				t.Errorf("Run() failed, got advice = %v, want = %v", result.Advice, tt.wantAdvice)
+			}
+		})
+	}
+}
+
+func TestFromConfigFix(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "generated.txt")
+
+	checks := FromConfig([]config.CheckConfig{
+		{Name: "generated", Type: config.CheckTypeFile, File: file, Fix: []string{"go", "generate", "./..."}},
+	})
+
+	result := checks[0].Run()
+	if result.Passed || result.Fix == nil {
+		t.Fatalf("Run() failed, got passed = %v, fix = %v, want a failed check with a fix", result.Passed, result.Fix)
+	}
+	if want := "Run 'go generate ./...'"; result.Fix.Description != want {
+		t.Errorf("Run() failed, got fix = %v, want = %v", result.Fix.Description, want)
+	}
+
+	if err := os.WriteFile(file, []byte("test"), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if result := checks[0].Run(); !result.Passed || result.Fix != nil {
+		t.Errorf("Run() failed, got passed = %v, fix = %v, want a passed check without fix", result.Passed, result.Fix)
+	}
+}
+
+func TestFromConfigDryRun(t *testing.T) {
+	osutil.SetDryRun(false, true)
+	t.Cleanup(func() { osutil.SetDryRun(false, false) })
+
+	checks := FromConfig([]config.CheckConfig{
+		{Name: "command", Type: config.CheckTypeCommand, Command: []string{"go", "env", "GODEV_DRY_RUN_CHECK"}},
+		{Name: "binary", Type: config.CheckTypeBinary, Binary: "go", MinVersion: "999.0", VersionArgs: []string{"version"}},
+	})
+	for _, check := range checks {
+		if result := check.Run(); !result.Passed || result.Message != "skipped in dry-run mode" {
+			t.Errorf("Run() of %s failed, got passed = %v (%s), want it skipped", check.Name(), result.Passed, result.Message)
+		}
+	}
+
+	var script strings.Builder
+	if err := osutil.WriteScript(&script); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"go env GODEV_DRY_RUN_CHECK", " version"} {
+		if !strings.Contains(script.String(), want) {
+			t.Errorf("WriteScript() = %q, want the planned command %q", script.String(), want)
+		}
+	}
+}
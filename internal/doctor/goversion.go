@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"go/version"
+	"os"
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// localGoVersion asks the go command on PATH for its own version, GOTOOLCHAIN=local
+// prevents it from switching to (or downloading) the toolchain required by go.mod
+func localGoVersion() (string, error) {
+	return osutil.CommandOutputWithEnv([]string{"GOTOOLCHAIN=local"}, "go", "env", "GOVERSION")
+}
+
+// goToolchainSetting returns the effective GOTOOLCHAIN, from the environment or the go env file
+func goToolchainSetting() string {
+	if value := os.Getenv("GOTOOLCHAIN"); value != strconst.Empty {
+		return value
+	}
+
+	// outside of the module, so that go.mod cannot make the go command switch toolchains
+	command := osutil.Command{Name: "go", Args: []string{"env", "GOTOOLCHAIN"}, Dir: os.TempDir()}
+	if output, err := osutil.CommandOutputContext(context.Background(), command); err == nil && output != strconst.Empty {
+		return output
+	}
+	return "auto"
+}
+
+// evaluateGoVersion tells whether the go command can build the module, following the toolchain
+// selection rules of https://go.dev/doc/toolchain: local is the installed version (e.g. go1.25.4),
+// goDirective and toolchainDirective come from go.mod (e.g. 1.25 and go1.25.5, the latter optional)
+func evaluateGoVersion(local, goDirective, toolchainDirective, gotoolchain string) *Result {
+	required := "go" + goDirective
+	message := fmt.Sprintf("installed: %s, required: %s", local, required)
+	if toolchainDirective != strconst.Empty {
+		message += ", toolchain: " + toolchainDirective
+	}
+
+	if !version.IsValid(required) {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("Invalid go directive %q in the go.mod file", goDirective),
+			Advice:  "Fix the go directive of the go.mod file, e.g. 'go 1.25'",
+		}
+	}
+
+	// development builds are newer than any release
+	if strings.HasPrefix(local, "devel") {
+		return &Result{Passed: true, Message: message + " (development build)"}
+	}
+	// GOEXPERIMENT builds add the experiments after a space, e.g. go1.22.0 X:rangefunc
+	local, _, _ = strings.Cut(local, strconst.Space)
+	if !version.IsValid(local) {
+		return &Result{
+			Passed:  false,
+			Message: fmt.Sprintf("Unable to parse the installed Go version %q", local),
+			Advice:  "Check the Go installation with 'go version'",
+		}
+	}
+
+	// GOTOOLCHAIN is local, auto (local+auto), path (local+path), <name>, <name>+auto or <name>+path
+	name, mode, _ := strings.Cut(gotoolchain, "+")
+	switch name {
+	case "auto", "path":
+		name, mode = "local", name
+	}
+	current := local
+	if name != "local" {
+		if !version.IsValid(name) {
+			return &Result{
+				Passed:  false,
+				Message: fmt.Sprintf("Invalid GOTOOLCHAIN %q", gotoolchain),
+				Advice:  "Set GOTOOLCHAIN to local, auto, path or a Go release like go1.25.5",
+			}
+		}
+		current = name
+		message += fmt.Sprintf(", GOTOOLCHAIN forces: %s", name)
+	}
+
+	// the newer of the toolchain and go directives wins when switching is allowed
+	target := current
+	if mode == "auto" || mode == "path" {
+		if toolchainDirective != strconst.Empty && version.Compare(toolchainDirective, target) > 0 {
+			target = toolchainDirective
+		}
+		if version.Compare(required, target) > 0 {
+			target = required
+		}
+	}
+
+	if version.Compare(target, required) < 0 {
+		return &Result{
+			Passed:  false,
+			Message: message,
+			Advice: fmt.Sprintf("Upgrade Go to version %s or higher, download from https://golang.org/dl/, or set GOTOOLCHAIN=auto to let the go command switch automatically (GOTOOLCHAIN=%s)",
+				required, gotoolchain),
+		}
+	}
+
+	if target != current {
+		source := "the toolchain directive"
+		if target == required {
+			source = "the go directive"
+		}
+		advice := fmt.Sprintf("Install %s locally to avoid the switch", target)
+		if mode == "auto" {
+			advice = fmt.Sprintf("Install %s locally to avoid downloading it", target)
+		}
+		return &Result{
+			Passed:   false,
+			Message:  fmt.Sprintf("%s, the go command will switch to %s as required by %s (GOTOOLCHAIN=%s)", message, target, source, gotoolchain),
+			Advice:   advice,
+			Severity: SeverityWarning,
+		}
+	}
+
+	return &Result{Passed: true, Message: message}
+}
@@ -0,0 +1,144 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateGoVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		local              string
+		goDirective        string
+		toolchainDirective string
+		gotoolchain        string
+		wantPassed         bool
+		wantSeverity       Severity
+		wantMessage        string
+	}{
+		{
+			name:        "newer minor version compares semantically",
+			local:       "go1.25.4",
+			goDirective: "1.9",
+			gotoolchain: "local",
+			wantPassed:  true,
+		},
+		{
+			name:         "older minor version fails with local toolchain",
+			local:        "go1.9.7",
+			goDirective:  "1.25",
+			gotoolchain:  "local",
+			wantPassed:   false,
+			wantSeverity: SeverityError,
+		},
+		{
+			name:        "release candidate satisfies language version",
+			local:       "go1.26rc1",
+			goDirective: "1.26",
+			gotoolchain: "local",
+			wantPassed:  true,
+		},
+		{
+			name:         "release candidate is older than the release",
+			local:        "go1.26rc1",
+			goDirective:  "1.26.0",
+			gotoolchain:  "local",
+			wantPassed:   false,
+			wantSeverity: SeverityError,
+		},
+		{
+			name:        "development build",
+			local:       "devel go1.27-abcdef Tue Jan 1 00:00:00 2030 +0000",
+			goDirective: "1.30",
+			gotoolchain: "local",
+			wantPassed:  true,
+			wantMessage: "development build",
+		},
+		{
+			name:        "experiment suffix",
+			local:       "go1.22.0 X:rangefunc",
+			goDirective: "1.22",
+			gotoolchain: "local",
+			wantPassed:  true,
+		},
+		{
+			name:         "experiment suffix of an older version",
+			local:        "go1.22.0 X:rangefunc",
+			goDirective:  "1.23",
+			gotoolchain:  "local",
+			wantPassed:   false,
+			wantSeverity: SeverityError,
+		},
+		{
+			name:         "auto switches to the go directive",
+			local:        "go1.24.0",
+			goDirective:  "1.25.1",
+			gotoolchain:  "auto",
+			wantPassed:   false,
+			wantSeverity: SeverityWarning,
+			wantMessage:  "will switch to go1.25.1 as required by the go directive",
+		},
+		{
+			name:               "auto switches to the newer toolchain directive",
+			local:              "go1.25.0",
+			goDirective:        "1.24",
+			toolchainDirective: "go1.25.5",
+			gotoolchain:        "local+auto",
+			wantPassed:         false,
+			wantSeverity:       SeverityWarning,
+			wantMessage:        "will switch to go1.25.5 as required by the toolchain directive",
+		},
+		{
+			name:               "older toolchain directive is ignored",
+			local:              "go1.25.5",
+			goDirective:        "1.24",
+			toolchainDirective: "go1.25.0",
+			gotoolchain:        "auto",
+			wantPassed:         true,
+		},
+		{
+			name:         "forced toolchain too old",
+			local:        "go1.26.0",
+			goDirective:  "1.25",
+			gotoolchain:  "go1.24.0",
+			wantPassed:   false,
+			wantSeverity: SeverityError,
+			wantMessage:  "GOTOOLCHAIN forces: go1.24.0",
+		},
+		{
+			name:        "forced toolchain new enough",
+			local:       "go1.20.0",
+			goDirective: "1.25",
+			gotoolchain: "go1.25.3",
+			wantPassed:  true,
+		},
+		{
+			name:         "invalid GOTOOLCHAIN",
+			local:        "go1.25.0",
+			goDirective:  "1.25",
+			gotoolchain:  "latest",
+			wantPassed:   false,
+			wantSeverity: SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateGoVersion(tt.local, tt.goDirective, tt.toolchainDirective, tt.gotoolchain)
+			if got.Passed != tt.wantPassed {
+				t.Fatalf("evaluateGoVersion() failed, got passed = %v (%s), want = %v", got.Passed, got.Message, tt.wantPassed)
+			}
+			// an empty severity of a failed check means error
+			gotSeverity := got.Severity
+			if !got.Passed && gotSeverity == "" {
+				gotSeverity = SeverityError
+			}
+			if !got.Passed && gotSeverity != tt.wantSeverity {
+				t.Errorf("evaluateGoVersion() failed, got severity = %v, want = %v", gotSeverity, tt.wantSeverity)
+			}
+			if !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("evaluateGoVersion() failed, got message = %v, want containing = %v", got.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package doctor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+type Severity string
+
+// the severity of a passed check is none, a failed check is an error unless it says otherwise
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNone    Severity = "none"
+)
+
+var Severities = []Severity{SeverityError, SeverityWarning}
+
+type CheckReport struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Message  string        `json:"message"`
+	Advice   string        `json:"advice,omitempty"`
+	Severity Severity      `json:"severity"`
+	Duration time.Duration `json:"duration"`
+	Source   string        `json:"source"`
+	Fixable  bool          `json:"fixable"`
+
+	description string
+	fix         *Fix
+}
+
+// Fix returns the automatic fix of the failed check, nil when there is none
+func (r CheckReport) Fix() *Fix {
+	return r.fix
+}
+
+// MarshalJSON renders the duration in the Go duration format, e.g. 1.5ms
+func (r CheckReport) MarshalJSON() ([]byte, error) {
+	type alias CheckReport
+	return json.Marshal(struct {
+		alias
+		Duration string `json:"duration"`
+	}{alias: alias(r), Duration: r.Duration.Round(time.Microsecond).String()})
+}
+
+type Report struct {
+	Checks   []CheckReport `json:"checks"`
+	Failures int           `json:"failures"`
+	Warnings int           `json:"warnings"`
+}
+
+// Run runs the checks in order and reports their results with timing
+func Run(checks []Check, onResult func(CheckReport)) *Report {
+	report := &Report{Checks: make([]CheckReport, 0, len(checks))}
+	for _, check := range checks {
+		start := time.Now()
+		result := check.Run()
+
+		r := CheckReport{
+			Name:        check.Name(),
+			Passed:      result.Passed,
+			Message:     result.Message,
+			Advice:      result.Advice,
+			Severity:    SeverityNone,
+			Duration:    time.Since(start),
+			Source:      SourceOf(check),
+			Fixable:     !result.Passed && result.Fix != nil,
+			description: check.Description(),
+		}
+		if r.Fixable {
+			r.fix = result.Fix
+		}
+		if !result.Passed {
+			r.Severity = SeverityError
+			if result.Severity != strconst.Empty {
+				r.Severity = result.Severity
+			}
+			if r.Severity == SeverityWarning {
+				report.Warnings++
+			} else {
+				report.Failures++
+			}
+		}
+
+		report.Checks = append(report.Checks, r)
+		if onResult != nil {
+			onResult(r)
+		}
+	}
+	return report
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log, one rule per check
+func (r *Report) WriteSARIF(w io.Writer, toolVersion string) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type rule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+	type result struct {
+		RuleID  string  `json:"ruleId"`
+		Kind    string  `json:"kind"`
+		Level   string  `json:"level"`
+		Message message `json:"message"`
+	}
+
+	rules := make([]rule, 0, len(r.Checks))
+	results := make([]result, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		rules = append(rules, rule{ID: c.Name, ShortDescription: message{Text: c.description}})
+
+		text := c.Message
+		if c.Advice != strconst.Empty {
+			text = strings.TrimSuffix(text, ".") + ". Remedy: " + c.Advice
+		}
+		kind := "pass"
+		if !c.Passed {
+			kind = "fail"
+		}
+		results = append(results, result{RuleID: c.Name, Kind: kind, Level: string(c.Severity), Message: message{Text: text}})
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{
+			map[string]any{
+				"tool": map[string]any{
+					"driver": map[string]any{
+						"name":           "godev doctor",
+						"informationUri": "https://github.com/thought2code/godev",
+						"version":        toolVersion,
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
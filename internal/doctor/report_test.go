@@ -0,0 +1,95 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		NewCheck("passed", "passed", func() *Result { return &Result{Passed: true, Message: "ok"} }),
+		NewCheck("failed", "failed", func() *Result {
+			return &Result{Passed: false, Message: "broken", Advice: "fix it", Fix: &Fix{Description: "fix", Apply: func() error { return nil }}}
+		}),
+		NewCheck("warned", "warned", func() *Result { return &Result{Passed: false, Message: "outdated", Severity: SeverityWarning} }),
+	}
+
+	var seen []string
+	report := Run(checks, func(r CheckReport) { seen = append(seen, r.Name) })
+
+	if len(seen) != len(checks) {
+		t.Errorf("Run() failed, got %d callbacks, want = %d", len(seen), len(checks))
+	}
+	if report.Failures != 1 || report.Warnings != 1 {
+		t.Errorf("Run() failed, got failures = %d, warnings = %d, want = 1, 1", report.Failures, report.Warnings)
+	}
+
+	if !report.Checks[1].Fixable || report.Checks[1].Fix() == nil || report.Checks[2].Fix() != nil {
+		t.Errorf("Run() failed, got unexpected fixes = %+v", report.Checks)
+	}
+
+	wantSeverities := []Severity{SeverityNone, SeverityError, SeverityWarning}
+	for i, r := range report.Checks {
+		if r.Severity != wantSeverities[i] {
+			t.Errorf("Run() failed, check %s got severity = %v, want = %v", r.Name, r.Severity, wantSeverities[i])
+		}
+	}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	report := Run([]Check{
+		NewCheck("failed", "failed", func() *Result { return &Result{Passed: false, Message: "broken", Advice: "fix it"} }),
+	}, nil)
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() failed, got unexpected error = %v", err)
+	}
+
+	var got struct {
+		Checks   []map[string]any `json:"checks"`
+		Failures int              `json:"failures"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() failed, got invalid JSON = %v", err)
+	}
+	if got.Failures != 1 || len(got.Checks) != 1 {
+		t.Fatalf("WriteJSON() failed, got = %s", buf.String())
+	}
+	for _, key := range []string{"name", "passed", "message", "advice", "severity", "duration", "source"} {
+		if _, ok := got.Checks[0][key]; !ok {
+			t.Errorf("WriteJSON() failed, missing field %q in %s", key, buf.String())
+		}
+	}
+}
+
+func TestReportWriteSARIF(t *testing.T) {
+	report := Run([]Check{
+		NewCheck("warned", "warned", func() *Result { return &Result{Passed: false, Message: "outdated", Severity: SeverityWarning} }),
+	}, nil)
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, "v0.0.0"); err != nil {
+		t.Fatalf("WriteSARIF() failed, got unexpected error = %v", err)
+	}
+
+	var got struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteSARIF() failed, got invalid JSON = %v", err)
+	}
+	if got.Version != "2.1.0" || len(got.Runs) != 1 || len(got.Runs[0].Results) != 1 {
+		t.Fatalf("WriteSARIF() failed, got = %s", buf.String())
+	}
+	if r := got.Runs[0].Results[0]; r.RuleID != "warned" || r.Level != "warning" {
+		t.Errorf("WriteSARIF() failed, got result = %+v", r)
+	}
+}
@@ -0,0 +1,68 @@
+package doctor
+
+import (
+	"fmt"
+	"go/version"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// evaluateToolVersion compares an installed tool with the version the project pins: installed is
+// the module version of the binary (e.g. v0.9.2), builtWith the Go release that built it
+// (e.g. go1.25.4) and goDirective the go directive of go.mod, empty when unknown
+func evaluateToolVersion(tool config.ToolConfig, installed, builtWith, goDirective string) *Result {
+	message := fmt.Sprintf("installed: %s built with %s, pinned: %s (%s)", installed, builtWith, tool.Version, tool.Source)
+	reinstall := fmt.Sprintf("Run 'godev tools install' to install %s version: %s", tool.Name, tool.Version)
+
+	if tool.Version != "latest" {
+		switch {
+		case !semver.IsValid(installed):
+			return &Result{
+				Passed:   false,
+				Message:  message + ", the installed version is unknown",
+				Advice:   reinstall,
+				Severity: SeverityWarning,
+			}
+		case !semver.IsValid(tool.Version):
+			return &Result{
+				Passed:   false,
+				Message:  fmt.Sprintf("Invalid pinned version %q (%s)", tool.Version, tool.Source),
+				Advice:   fmt.Sprintf("Pin %s to a semantic version like v1.2.3 in %s", tool.Name, tool.Source),
+				Severity: SeverityWarning,
+			}
+		case semver.Compare(installed, tool.Version) < 0:
+			return &Result{
+				Passed:   false,
+				Message:  message + ", the installed version is older",
+				Advice:   reinstall,
+				Severity: SeverityWarning,
+			}
+		case semver.Compare(installed, tool.Version) > 0:
+			return &Result{
+				Passed:   false,
+				Message:  message + ", the installed version is newer",
+				Advice:   reinstall + ", or pin the newer version in " + tool.Source,
+				Severity: SeverityWarning,
+			}
+		}
+	}
+
+	// tools like golangci-lint cannot analyze code using a newer Go than the one they are built with
+	if goDirective != strconst.Empty && version.IsValid(builtWith) && version.Compare(builtWith, "go"+goDirective) < 0 {
+		return &Result{
+			Passed:   false,
+			Message:  fmt.Sprintf("%s, older than the go %s directive of go.mod", message, goDirective),
+			Advice:   fmt.Sprintf("Rebuild %s with a newer Go by running 'godev tools install'", tool.Name),
+			Severity: SeverityWarning,
+		}
+	}
+
+	return &Result{
+		Passed:  true,
+		Message: message,
+		Advice:  strconst.Empty,
+	}
+}
@@ -0,0 +1,131 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/thought2code/godev/internal/config"
+)
+
+func TestEvaluateToolVersion(t *testing.T) {
+	pinned := config.ToolConfig{Name: "gofumpt", Package: "mvdan.cc/gofumpt", Version: "v0.9.2", Source: config.ToolSourceGoMod}
+
+	tests := []struct {
+		name        string
+		tool        config.ToolConfig
+		installed   string
+		builtWith   string
+		goDirective string
+		wantPassed  bool
+		wantMessage string
+	}{
+		{
+			name:        "pinned version installed",
+			tool:        pinned,
+			installed:   "v0.9.2",
+			builtWith:   "go1.25.4",
+			goDirective: "1.25",
+			wantPassed:  true,
+			wantMessage: "pinned: v0.9.2 (go.mod)",
+		},
+		{
+			name:        "older version installed",
+			tool:        pinned,
+			installed:   "v0.8.0",
+			builtWith:   "go1.25.4",
+			wantPassed:  false,
+			wantMessage: "older",
+		},
+		{
+			name:        "newer version installed",
+			tool:        pinned,
+			installed:   "v0.10.0",
+			builtWith:   "go1.25.4",
+			wantPassed:  false,
+			wantMessage: "newer",
+		},
+		{
+			name:        "development build of the tool",
+			tool:        pinned,
+			installed:   "(devel)",
+			builtWith:   "go1.25.4",
+			wantPassed:  false,
+			wantMessage: "unknown",
+		},
+		{
+			name:        "built with an older Go than the module",
+			tool:        pinned,
+			installed:   "v0.9.2",
+			builtWith:   "go1.24.9",
+			goDirective: "1.25.0",
+			wantPassed:  false,
+			wantMessage: "older than the go 1.25.0 directive",
+		},
+		{
+			name:       "latest skips the version comparison",
+			tool:       config.ToolConfig{Name: "goimports", Version: "latest", Source: config.ToolSourceConfig},
+			installed:  "v0.1.0",
+			builtWith:  "go1.25.4",
+			wantPassed: true,
+		},
+		{
+			name:        "invalid pinned version",
+			tool:        config.ToolConfig{Name: "goimports", Version: "main", Source: config.ToolSourceConfig},
+			installed:   "v0.1.0",
+			builtWith:   "go1.25.4",
+			wantPassed:  false,
+			wantMessage: "Invalid pinned version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateToolVersion(tt.tool, tt.installed, tt.builtWith, tt.goDirective)
+			if got.Passed != tt.wantPassed {
+				t.Fatalf("evaluateToolVersion() failed, got passed = %v (%s), want = %v", got.Passed, got.Message, tt.wantPassed)
+			}
+			if !got.Passed && got.Severity != SeverityWarning {
+				t.Errorf("evaluateToolVersion() failed, got severity = %v, want = %v", got.Severity, SeverityWarning)
+			}
+			if !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("evaluateToolVersion() failed, got message = %v, want containing = %v", got.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCheckGoToolsVersion(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "godev-script-tool"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	t.Setenv("PATH", tempDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tests := []struct {
+		name        string
+		tool        config.ToolConfig
+		wantMessage string
+	}{
+		{name: "not installed", tool: config.ToolConfig{Name: "godev-missing-tool", Version: "v1.0.0"}, wantMessage: "Not installed"},
+		{name: "no build information", tool: config.ToolConfig{Name: "godev-script-tool", Version: "v1.0.0"}, wantMessage: "Unable to read the build information"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.name == "no build information" {
+				t.Skip("the script is not an executable on windows")
+			}
+			got := checkGoToolsVersion(tt.tool, func(config.ToolConfig) error { return nil })
+			if got.Passed || got.Severity != SeverityError || got.Fix == nil {
+				t.Errorf("checkGoToolsVersion() failed, got passed = %v, severity = %v, fix = %v, want a failed error with a fix", got.Passed, got.Severity, got.Fix)
+			}
+			if !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("checkGoToolsVersion() failed, got message = %v, want containing = %v", got.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package gitutil
+
+import (
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Describe returns the most recent tag reachable from HEAD, e.g. v1.2.0-3-gabc1234-dirty,
+// falling back to the abbreviated commit hash when the repository has no tags
+func Describe() (string, error) {
+	return osutil.CommandOutput("git", "describe", "--tags", "--always", "--dirty")
+}
+
+func ShortCommit() (string, error) {
+	return osutil.CommandOutput("git", "rev-parse", "--short", "HEAD")
+}
+
+type Commit struct {
+	Hash    string
+	Subject string
+}
+
+// Tags lists the tags of the repository, newest version first
+func Tags() ([]string, error) {
+	output, err := osutil.CommandOutput("git", "tag", "--list", "--sort=-v:refname")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(output), nil
+}
+
+// Log lists the commits in the revision range, e.g. v1.0.0..HEAD, newest first
+func Log(revRange string) ([]Commit, error) {
+	output, err := osutil.CommandOutput("git", "log", "--no-merges", "--pretty=format:%h%x09%s", revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range splitLines(output) {
+		hash, subject, _ := strings.Cut(line, "\t")
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
+
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, strconst.NewLine) {
+		if line = strings.TrimSpace(line); line != strconst.Empty {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// MergeBase returns the best common ancestor of HEAD and the base revision, e.g. origin/main
+func MergeBase(base string) (string, error) {
+	return osutil.CommandOutput("git", "merge-base", base, "HEAD")
+}
+
+// ChangedFiles lists the files changed since the revision, committed or not, and the untracked
+// ones, relative to the current directory and limited to it. Deleted files are listed too
+func ChangedFiles(rev string) ([]string, error) {
+	changed, err := osutil.CommandOutput("git", "diff", "--name-only", "--relative", rev)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := osutil.CommandOutput("git", "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return append(splitLines(changed), splitLines(untracked)...), nil
+}
+
+// UserName returns the user.name of the git configuration, empty when it is not set
+func UserName() string {
+	name, err := osutil.CommandOutput("git", "config", "--get", "user.name")
+	if err != nil {
+		return strconst.Empty
+	}
+	return name
+}
@@ -0,0 +1,109 @@
+package gobuild
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Package is a package of the current module with what it and its tests depend on
+type Package struct {
+	Dir        string
+	ImportPath string
+	// Deps are the transitive dependencies of the package, as listed by 'go list -deps'
+	Deps []string
+	// TestImports are the direct imports of the test files, internal and external
+	TestImports []string
+}
+
+// ListPackages lists the packages of the current module
+func ListPackages() ([]Package, error) {
+	// the directory comes last, the trimmed output would lose the empty fields of the last line
+	format := `{{.ImportPath}}{{"\t"}}{{join .Deps " "}}{{"\t"}}{{join .TestImports " "}} {{join .XTestImports " "}}{{"\t"}}{{.Dir}}`
+	output, err := osutil.CommandOutput("go", "list", "-e", "-f", format, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, line := range strings.Split(output, strconst.NewLine) {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		packages = append(packages, Package{
+			ImportPath:  fields[0],
+			Deps:        strings.Fields(fields[1]),
+			TestImports: strings.Fields(fields[2]),
+			Dir:         fields[3],
+		})
+	}
+	return packages, nil
+}
+
+// AffectedPackages returns the import paths of the packages containing the changed files and of
+// the packages whose code or tests depend on them, sorted. A changed file belongs to the package
+// of its nearest enclosing directory, like testdata or embedded files, and a change of go.mod or
+// go.sum affects every package
+func AffectedPackages(packages []Package, changedFiles []string) []string {
+	dirs := make(map[string]string, len(packages))
+	deps := make(map[string][]string, len(packages))
+	for _, pkg := range packages {
+		dirs[filepath.Clean(pkg.Dir)] = pkg.ImportPath
+		deps[pkg.ImportPath] = pkg.Deps
+	}
+
+	changed := make(map[string]bool)
+	for _, file := range changedFiles {
+		if name := filepath.Base(file); name == "go.mod" || name == "go.sum" {
+			return allPackages(packages)
+		}
+		for dir := filepath.Dir(filepath.Clean(file)); ; dir = filepath.Dir(dir) {
+			if importPath, ok := dirs[dir]; ok {
+				changed[importPath] = true
+				break
+			}
+			if parent := filepath.Dir(dir); parent == dir {
+				break
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	dependsOnChange := func(imports []string) bool {
+		for _, imp := range imports {
+			if changed[imp] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var affected []string
+	for _, pkg := range packages {
+		hit := changed[pkg.ImportPath] || dependsOnChange(pkg.Deps) || dependsOnChange(pkg.TestImports)
+		// Deps is already transitive, while the test imports are direct ones
+		for _, imp := range pkg.TestImports {
+			hit = hit || dependsOnChange(deps[imp])
+		}
+		if hit && !slices.Contains(affected, pkg.ImportPath) {
+			affected = append(affected, pkg.ImportPath)
+		}
+	}
+	slices.Sort(affected)
+	return affected
+}
+
+func allPackages(packages []Package) []string {
+	all := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		all = append(all, pkg.ImportPath)
+	}
+	slices.Sort(all)
+	return all
+}
@@ -0,0 +1,78 @@
+package gobuild
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAffectedPackages(t *testing.T) {
+	packages := []Package{
+		{Dir: "/m", ImportPath: "example.com/m", Deps: []string{"example.com/m/api", "example.com/m/store", "fmt"}},
+		{Dir: "/m/api", ImportPath: "example.com/m/api", Deps: []string{"example.com/m/store", "net/http"}},
+		{Dir: "/m/store", ImportPath: "example.com/m/store", Deps: []string{"database/sql"}},
+		{Dir: "/m/testutil", ImportPath: "example.com/m/testutil", Deps: []string{"testing"}},
+		{Dir: "/m/util", ImportPath: "example.com/m/util", TestImports: []string{"example.com/m/api", "testing"}},
+	}
+
+	all := []string{"example.com/m", "example.com/m/api", "example.com/m/store", "example.com/m/testutil", "example.com/m/util"}
+
+	tests := []struct {
+		name         string
+		changedFiles []string
+		want         []string
+	}{
+		{
+			name:         "nothing changed",
+			changedFiles: nil,
+			want:         nil,
+		},
+		{
+			name:         "leaf package with reverse dependencies",
+			changedFiles: []string{"/m/store/store.go"},
+			want:         []string{"example.com/m", "example.com/m/api", "example.com/m/store", "example.com/m/util"},
+		},
+		{
+			name:         "main package only",
+			changedFiles: []string{"/m/main.go"},
+			want:         []string{"example.com/m"},
+		},
+		{
+			name:         "package imported by tests",
+			changedFiles: []string{"/m/api/handler.go"},
+			want:         []string{"example.com/m", "example.com/m/api", "example.com/m/util"},
+		},
+		{
+			name:         "testdata of a package",
+			changedFiles: []string{"/m/store/testdata/fixtures/users.json"},
+			want:         []string{"example.com/m", "example.com/m/api", "example.com/m/store", "example.com/m/util"},
+		},
+		{
+			name:         "embedded file in a subdirectory",
+			changedFiles: []string{"/m/testutil/static/index.html"},
+			want:         []string{"example.com/m/testutil"},
+		},
+		{
+			name:         "dependency bump in go.mod",
+			changedFiles: []string{"/m/go.mod"},
+			want:         all,
+		},
+		{
+			name:         "go.sum only",
+			changedFiles: []string{"/m/go.sum"},
+			want:         all,
+		},
+		{
+			name:         "file outside of the module",
+			changedFiles: []string{"/other/README.md"},
+			want:         nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AffectedPackages(packages, tt.changedFiles); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AffectedPackages() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gobuild
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+type Platform struct {
+	GOOS   string
+	GOARCH string
+}
+
+func (p Platform) String() string {
+	return p.GOOS + "/" + p.GOARCH
+}
+
+// ParsePlatforms parses platforms in the form of GOOS/GOARCH, e.g. linux/amd64
+func ParsePlatforms(specs []string) ([]Platform, error) {
+	platforms := make([]Platform, 0, len(specs))
+	seen := make(map[Platform]bool, len(specs))
+	for _, spec := range specs {
+		goos, goarch, ok := strings.Cut(strings.TrimSpace(spec), "/")
+		if !ok || goos == strconst.Empty || goarch == strconst.Empty || strings.Contains(goarch, "/") {
+			return nil, fmt.Errorf("invalid platform %q, expected GOOS/GOARCH", spec)
+		}
+		platform := Platform{GOOS: goos, GOARCH: goarch}
+		if seen[platform] {
+			continue
+		}
+		seen[platform] = true
+		platforms = append(platforms, platform)
+	}
+	return platforms, nil
+}
+
+type Options struct {
+	Packages  []string
+	Platforms []Platform
+	OutputDir string
+	TrimPath  bool
+	CGO       bool
+	LDFlags   string
+
+	// VersionPkg is the package holding the version, commit and date string variables
+	VersionPkg string
+	Version    string
+	Commit     string
+	Date       string
+}
+
+type Artifact struct {
+	Platform Platform
+	Package  string
+	Name     string
+	Path     string
+	Size     int64
+}
+
+// MainPackages lists the import paths of all main packages in the current module
+func MainPackages() ([]string, error) {
+	output, err := osutil.CommandOutput("go", "list", "-f", `{{if eq .Name "main"}}{{.ImportPath}}{{end}}`, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []string
+	for _, line := range strings.Split(output, strconst.NewLine) {
+		if line = strings.TrimSpace(line); line != strconst.Empty {
+			packages = append(packages, line)
+		}
+	}
+	return packages, nil
+}
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// BinaryName derives the binary name from the package import path the same way 'go install' does, with the .exe suffix when goos is windows
+func BinaryName(pkg, goos string) string {
+	name := path.Base(pkg)
+	if majorVersionSuffix.MatchString(name) && path.Dir(pkg) != "." {
+		name = path.Base(path.Dir(pkg))
+	}
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// LDFlags builds the -ldflags value that injects the version metadata into the binary
+func LDFlags(opts Options) string {
+	flags := []string{
+		fmt.Sprintf("-X %s.version=%s", opts.VersionPkg, opts.Version),
+		fmt.Sprintf("-X %s.commit=%s", opts.VersionPkg, opts.Commit),
+		fmt.Sprintf("-X %s.date=%s", opts.VersionPkg, opts.Date),
+	}
+	if opts.LDFlags != strconst.Empty {
+		flags = append(flags, opts.LDFlags)
+	}
+	return strings.Join(flags, strconst.Space)
+}
+
+// ArtifactPath returns where the binary of pkg built for platform is written, e.g. dist/linux_amd64/app
+func ArtifactPath(outputDir string, platform Platform, pkg string) string {
+	return filepath.Join(outputDir, platform.GOOS+"_"+platform.GOARCH, BinaryName(pkg, platform.GOOS))
+}
+
+func Build(ctx context.Context, opts Options) ([]Artifact, error) {
+	ldflags := LDFlags(opts)
+	cgoEnabled := "0"
+	if opts.CGO {
+		cgoEnabled = "1"
+	}
+
+	var artifacts []Artifact
+	for _, platform := range opts.Platforms {
+		env := []string{
+			"GOOS=" + platform.GOOS,
+			"GOARCH=" + platform.GOARCH,
+			"CGO_ENABLED=" + cgoEnabled,
+		}
+		for _, pkg := range opts.Packages {
+			output := ArtifactPath(opts.OutputDir, platform, pkg)
+
+			args := []string{"build", "-o", output}
+			if opts.TrimPath {
+				args = append(args, "-trimpath")
+			}
+			args = append(args, "-ldflags", ldflags, pkg)
+
+			if err := osutil.RunCommandContext(ctx, osutil.Command{Name: "go", Args: args, Env: env}); err != nil {
+				return artifacts, fmt.Errorf("failed to build %s for %s: %w", pkg, platform, err)
+			}
+
+			artifact := Artifact{
+				Platform: platform,
+				Package:  pkg,
+				Name:     filepath.Base(output),
+				Path:     output,
+			}
+			// nothing is built in dry-run mode
+			if !osutil.DryRun() {
+				info, err := os.Stat(output)
+				if err != nil {
+					return artifacts, fmt.Errorf("failed to stat artifact %s: %w", output, err)
+				}
+				artifact.Size = info.Size()
+			}
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+func ModulePath() (string, error) {
+	return osutil.CommandOutput("go", "list", "-m")
+}
@@ -0,0 +1,101 @@
+package gobuild
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		want    []Platform
+		wantErr bool
+	}{
+		{
+			name:    "single platform",
+			specs:   []string{"linux/amd64"},
+			want:    []Platform{{GOOS: "linux", GOARCH: "amd64"}},
+			wantErr: false,
+		},
+		{
+			name:    "duplicated platforms are removed",
+			specs:   []string{"linux/amd64", " darwin/arm64", "linux/amd64"},
+			want:    []Platform{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "darwin", GOARCH: "arm64"}},
+			wantErr: false,
+		},
+		{
+			name:    "missing arch",
+			specs:   []string{"linux"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "too many parts",
+			specs:   []string{"linux/arm/v7"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := ParsePlatforms(tt.specs)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ParsePlatforms() failed, got unexpected error = %v", gotErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePlatforms() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinaryName(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  string
+		goos string
+		want string
+	}{
+		{name: "module root package", pkg: "github.com/thought2code/godev", goos: "linux", want: "godev"},
+		{name: "nested main package", pkg: "example.com/app/cmd/server", goos: "darwin", want: "server"},
+		{name: "major version suffix", pkg: "example.com/app/v2", goos: "linux", want: "app"},
+		{name: "major version in the middle", pkg: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint", goos: "linux", want: "golangci-lint"},
+		{name: "version-like single element", pkg: "v2", goos: "linux", want: "v2"},
+		{name: "host platform", pkg: "mvdan.cc/gofumpt", goos: "", want: "gofumpt"},
+		{name: "windows executable", pkg: "example.com/app", goos: "windows", want: "app.exe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BinaryName(tt.pkg, tt.goos); got != tt.want {
+				t.Errorf("BinaryName() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLDFlags(t *testing.T) {
+	opts := Options{
+		VersionPkg: "main",
+		Version:    "v1.0.0",
+		Commit:     "abc1234",
+		Date:       "2025-01-01T00:00:00Z",
+		LDFlags:    "-s -w",
+	}
+	want := "-X main.version=v1.0.0 -X main.commit=abc1234 -X main.date=2025-01-01T00:00:00Z -s -w"
+	if got := LDFlags(opts); got != want {
+		t.Errorf("LDFlags() failed, got = %v, want = %v", got, want)
+	}
+}
+
+func TestArtifactPath(t *testing.T) {
+	got := ArtifactPath("dist", Platform{GOOS: "windows", GOARCH: "amd64"}, "example.com/app/cmd/cli")
+	want := filepath.Join("dist", "windows_amd64", "cli.exe")
+	if got != want {
+		t.Errorf("ArtifactPath() failed, got = %v, want = %v", got, want)
+	}
+}
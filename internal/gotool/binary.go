@@ -0,0 +1,224 @@
+package gotool
+
+import (
+	"debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Env is the part of 'go env' locating installed tools and downloaded modules
+type Env struct {
+	GOBIN      string
+	GOMODCACHE string
+	GOEXE      string
+	GOPROXY    string
+}
+
+// ReadEnv asks the go command where 'go install' puts binaries and where the module cache is
+func ReadEnv() (Env, error) {
+	output, err := osutil.CommandOutputWithEnv([]string{"GOTOOLCHAIN=local"}, "go", "env", "-json", "GOBIN", "GOPATH", "GOMODCACHE", "GOEXE", "GOPROXY")
+	if err != nil {
+		return Env{}, err
+	}
+	return parseEnv([]byte(output))
+}
+
+// parseEnv parses the output of 'go env -json', which keeps the unset values like GOBIN
+func parseEnv(output []byte) (Env, error) {
+	var values struct {
+		Env
+		GOPATH string
+	}
+	if err := json.Unmarshal(output, &values); err != nil {
+		return Env{}, fmt.Errorf("invalid output of go env: %w", err)
+	}
+
+	env := values.Env
+	if env.GOBIN == strconst.Empty {
+		// go install uses the bin directory of the first GOPATH entry
+		gopath := filepath.SplitList(values.GOPATH)
+		if len(gopath) == 0 {
+			return Env{}, errors.New("neither GOBIN nor GOPATH is set")
+		}
+		env.GOBIN = filepath.Join(gopath[0], "bin")
+	}
+	return env, nil
+}
+
+// BinaryPath is where 'go install' puts the binary of the named tool
+func (e Env) BinaryPath(name string) string {
+	return filepath.Join(e.GOBIN, name+e.GOEXE)
+}
+
+// LocalProxy is the GOPROXY serving the module cache first, so that the go command resolves
+// cached modules without network access, falling back to the configured proxies for the rest
+func (e Env) LocalProxy() string {
+	dir := filepath.ToSlash(filepath.Join(e.GOMODCACHE, "cache", "download"))
+	if !strings.HasPrefix(dir, "/") {
+		// file URLs of Windows paths look like file:///C:/Users
+		dir = "/" + dir
+	}
+	if e.GOPROXY == strconst.Empty || e.GOPROXY == "off" {
+		return "file://" + dir
+	}
+	return "file://" + dir + "," + e.GOPROXY
+}
+
+// Binary is an installed tool binary and the build information embedded by the go command
+type Binary struct {
+	Path      string
+	Module    string
+	Version   string
+	GoVersion string
+}
+
+// ReadBinary reads the build information of the binary at path
+func ReadBinary(path string) (*Binary, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Binary{Path: path, Module: info.Main.Path, Version: info.Main.Version, GoVersion: info.GoVersion}, nil
+}
+
+// LocalBinDir is the project-scoped GOBIN of 'godev tools install --local', relative to the module root
+var LocalBinDir = filepath.Join(".godev", "bin")
+
+// LocalGOBIN is the absolute path of LocalBinDir in the module root, the first directory
+// containing go.mod from the current directory up, or in the current directory out of a module
+func LocalGOBIN() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return strconst.Empty, err
+	}
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return strconst.Empty, err
+	}
+	return filepath.Join(root, LocalBinDir), nil
+}
+
+// moduleRoot walks up from dir to the directory containing go.mod, dir when there is none
+func moduleRoot(dir string) (string, error) {
+	for current := dir; ; {
+		exist, err := osutil.CheckExist(filepath.Join(current, "go.mod"))
+		if err != nil {
+			return strconst.Empty, err
+		}
+		if exist {
+			return current, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir, nil
+		}
+		current = parent
+	}
+}
+
+// LookPath finds the named tool in LocalBinDir, then on PATH
+func LookPath(name string) (string, error) {
+	if binPath, ok := localBinary(name); ok {
+		return binPath, nil
+	}
+	return exec.LookPath(name)
+}
+
+func localBinary(name string) (string, bool) {
+	dir, err := LocalGOBIN()
+	if err != nil {
+		return strconst.Empty, false
+	}
+	// LookPath adds the executable suffix of Windows
+	binPath, err := exec.LookPath(filepath.Join(dir, name))
+	return binPath, err == nil
+}
+
+// IsLocal tells whether the binary is installed in LocalBinDir
+func (b *Binary) IsLocal() bool {
+	dir, err := LocalGOBIN()
+	return err == nil && filepath.Dir(b.Path) == dir
+}
+
+// LookBinary finds the named tool like LookPath, then in GOBIN, returning an error wrapping
+// os.ErrNotExist when it is not installed
+func LookBinary(env Env, name string) (*Binary, error) {
+	binPath, err := LookPath(name)
+	if err != nil {
+		binPath = env.BinaryPath(name)
+		if exist, _ := osutil.CheckExist(binPath); !exist {
+			return nil, fmt.Errorf("%s is not installed: %w", name, os.ErrNotExist)
+		}
+	}
+	return ReadBinary(binPath)
+}
+
+// LatestCachedVersion returns the newest version of the module downloaded to the module cache,
+// which the go command can install offline with GOPROXY=file://$GOMODCACHE/cache/download,
+// preferring releases over pre-releases and pseudo-versions
+func LatestCachedVersion(modCache, modulePath string) (string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return strconst.Empty, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(modCache, "cache", "download", escaped, "@v"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return strconst.Empty, fmt.Errorf("module %s is not in the module cache: %w", modulePath, err)
+		}
+		return strconst.Empty, err
+	}
+
+	latest, latestPrerelease := strconst.Empty, strconst.Empty
+	for _, entry := range entries {
+		// versions only known from .info or .mod files have no source to build from
+		escapedVersion, ok := strings.CutSuffix(entry.Name(), ".zip")
+		if !ok {
+			continue
+		}
+		v, err := module.UnescapeVersion(escapedVersion)
+		if err != nil || !semver.IsValid(v) {
+			continue
+		}
+
+		if semver.Prerelease(v) == strconst.Empty {
+			if latest == strconst.Empty || semver.Compare(v, latest) > 0 {
+				latest = v
+			}
+		} else if latestPrerelease == strconst.Empty || semver.Compare(v, latestPrerelease) > 0 {
+			latestPrerelease = v
+		}
+	}
+
+	if latest == strconst.Empty {
+		latest = latestPrerelease
+	}
+	if latest == strconst.Empty {
+		return strconst.Empty, fmt.Errorf("no version of module %s in the module cache", modulePath)
+	}
+	return latest, nil
+}
+
+// CachedModule finds the module providing the package with a downloaded version in the module
+// cache, trying the longest module path first
+func CachedModule(modCache, pkg string) (string, bool) {
+	for modulePath := pkg; modulePath != "."; modulePath = path.Dir(modulePath) {
+		if _, err := LatestCachedVersion(modCache, modulePath); err == nil {
+			return modulePath, true
+		}
+	}
+	return strconst.Empty, false
+}
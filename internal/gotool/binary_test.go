@@ -0,0 +1,203 @@
+package gotool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeCachedVersions(t *testing.T, modCache, escapedModule string, files ...string) {
+	t.Helper()
+	dir := filepath.Join(modCache, "cache", "download", escapedModule, "@v")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLatestCachedVersion(t *testing.T) {
+	modCache := t.TempDir()
+	writeCachedVersions(t, modCache, "mvdan.cc/gofumpt",
+		"list", "v0.8.0.info", "v0.8.0.mod", "v0.8.0.zip", "v0.9.2.zip", "v0.10.0.mod", "v0.11.0-rc.1.zip")
+	writeCachedVersions(t, modCache, "example.com/pre",
+		"v0.0.0-20200513141252-abc0db2c416a.zip", "v0.1.0-rc.1.zip", "v0.2.0.mod")
+	writeCachedVersions(t, modCache, "github.com/!burnt!sushi/toml", "v1.5.0.zip")
+	writeCachedVersions(t, modCache, "example.com/empty", "list", "v1.0.0.info", "v1.0.0.mod")
+
+	tests := []struct {
+		name       string
+		modulePath string
+		want       string
+		wantErr    bool
+	}{
+		{name: "newest release wins over pre-release", modulePath: "mvdan.cc/gofumpt", want: "v0.9.2"},
+		{name: "pre-release when there is no release", modulePath: "example.com/pre", want: "v0.1.0-rc.1"},
+		{name: "escaped module path", modulePath: "github.com/BurntSushi/toml", want: "v1.5.0"},
+		{name: "no downloaded source", modulePath: "example.com/empty", wantErr: true},
+		{name: "module not in the cache", modulePath: "example.com/missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LatestCachedVersion(modCache, tt.modulePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LatestCachedVersion() failed, got error = %v, want error = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LatestCachedVersion() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachedModule(t *testing.T) {
+	modCache := t.TempDir()
+	writeCachedVersions(t, modCache, "golang.org/x/tools", "v0.40.0.zip")
+	writeCachedVersions(t, modCache, "golang.org/x/tools/cmd/goimports", "list")
+	writeCachedVersions(t, modCache, "mvdan.cc/gofumpt", "v0.9.2.zip")
+
+	tests := []struct {
+		pkg    string
+		want   string
+		wantOk bool
+	}{
+		{pkg: "golang.org/x/tools/cmd/goimports", want: "golang.org/x/tools", wantOk: true},
+		{pkg: "mvdan.cc/gofumpt", want: "mvdan.cc/gofumpt", wantOk: true},
+		{pkg: "honnef.co/go/tools/cmd/staticcheck", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			got, ok := CachedModule(modCache, tt.pkg)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CachedModule() failed, got = %v, %v, want = %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestLocalProxy(t *testing.T) {
+	modCache := filepath.Join(t.TempDir(), "mod")
+	local := "file://" + filepath.ToSlash(filepath.Join(modCache, "cache", "download"))
+	if !strings.HasPrefix(local, "file:///") {
+		local = "file:///" + strings.TrimPrefix(local, "file://")
+	}
+
+	tests := []struct {
+		goproxy string
+		want    string
+	}{
+		{goproxy: "https://proxy.golang.org,direct", want: local + ",https://proxy.golang.org,direct"},
+		{goproxy: "off", want: local},
+		{goproxy: "", want: local},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goproxy, func(t *testing.T) {
+			env := Env{GOMODCACHE: modCache, GOPROXY: tt.goproxy}
+			if got := env.LocalProxy(); got != tt.want {
+				t.Errorf("LocalProxy() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookPathPrefersLocalBinDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(LocalBinDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	name := "godev-test-tool"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	if err := os.WriteFile(filepath.Join(LocalBinDir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	want, err := filepath.Abs(filepath.Join(LocalBinDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LookPath("godev-test-tool")
+	if err != nil || got != want {
+		t.Errorf("LookPath() failed, got = %v, %v, want = %v", got, err, want)
+	}
+	if got := Command(nil, "godev-test-tool", "-l"); !reflect.DeepEqual(got, []string{want, "-l"}) {
+		t.Errorf("Command() failed, got = %v, want the local binary", got)
+	}
+	if _, err := LookPath("godev-missing-tool"); err == nil {
+		t.Errorf("LookPath() failed, want error for a missing tool")
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    Env
+		wantErr bool
+	}{
+		{
+			name:   "empty GOBIN",
+			output: `{"GOBIN": "", "GOEXE": "", "GOMODCACHE": "/mod", "GOPATH": "/go", "GOPROXY": "https://proxy.golang.org,direct"}`,
+			want:   Env{GOBIN: filepath.Join("/go", "bin"), GOMODCACHE: "/mod", GOPROXY: "https://proxy.golang.org,direct"},
+		},
+		{
+			name:   "GOBIN set",
+			output: `{"GOBIN": "/bin", "GOEXE": ".exe", "GOMODCACHE": "/mod", "GOPATH": "/go", "GOPROXY": "off"}`,
+			want:   Env{GOBIN: "/bin", GOEXE: ".exe", GOMODCACHE: "/mod", GOPROXY: "off"},
+		},
+		{name: "neither GOBIN nor GOPATH", output: `{"GOBIN": "", "GOPATH": ""}`, wantErr: true},
+		{name: "invalid output", output: "\n/go\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEnv([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEnv() failed, err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseEnv() failed, got = %+v, want = %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalGOBINFromSubdirectory(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "internal", "server")
+	if err := os.MkdirAll(filepath.Join(root, LocalBinDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	name := "godev-test-tool"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	want := filepath.Join(root, LocalBinDir, name)
+	if err := os.WriteFile(want, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+
+	if got, err := LocalGOBIN(); err != nil || got != filepath.Join(root, LocalBinDir) {
+		t.Errorf("LocalGOBIN() failed, got = %v, %v, want = %v", got, err, filepath.Join(root, LocalBinDir))
+	}
+	if got, err := LookPath("godev-test-tool"); err != nil || got != want {
+		t.Errorf("LookPath() failed, got = %v, %v, want = %v", got, err, want)
+	}
+}
@@ -0,0 +1,151 @@
+package gotool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/gobuild"
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// ModuleTool is a tool declared by a tool directive of go.mod
+type ModuleTool struct {
+	// Package is the import path of the tool, e.g. mvdan.cc/gofumpt
+	Package string
+	// Module and Version are from the require directive of the module providing the package
+	Module  string
+	Version string
+}
+
+// Name is the binary name of the tool, as accepted by 'go tool <name>'
+func (t ModuleTool) Name() string {
+	return gobuild.BinaryName(t.Package, strconst.Empty)
+}
+
+// ReadModuleTools reads the tool directives of the go.mod file, an absent file has no tools
+func ReadModuleTools(goModPath string) ([]ModuleTool, error) {
+	exist, err := osutil.CheckExist(goModPath)
+	if err != nil || !exist {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ModuleTools(mod), nil
+}
+
+func ModuleTools(mod *modfile.File) []ModuleTool {
+	tools := make([]ModuleTool, 0, len(mod.Tool))
+	for _, tool := range mod.Tool {
+		t := ModuleTool{Package: tool.Path}
+
+		// the longest module path containing the package provides it
+		for _, req := range mod.Require {
+			modPath := req.Mod.Path
+			if (t.Package == modPath || strings.HasPrefix(t.Package, modPath+"/")) && len(modPath) > len(t.Module) {
+				t.Module, t.Version = modPath, req.Mod.Version
+			}
+		}
+		if t.Module == strconst.Empty && mod.Module != nil && strings.HasPrefix(t.Package, mod.Module.Mod.Path+"/") {
+			// a tool of the main module itself
+			t.Module = mod.Module.Mod.Path
+		}
+		tools = append(tools, t)
+	}
+	return tools
+}
+
+// ApplyModulePins pins the versions of the tools declared in go.mod, appending the module tools
+// godev does not know about, so that the project and not godev decides the versions
+func ApplyModulePins(tools []config.ToolConfig, moduleTools []ModuleTool) []config.ToolConfig {
+	pinned := append([]config.ToolConfig(nil), tools...)
+	for _, mt := range moduleTools {
+		// tools of the main module are built from source, there is nothing to pin
+		if mt.Version == strconst.Empty {
+			continue
+		}
+
+		i := 0
+		for ; i < len(pinned) && pinned[i].Package != mt.Package; i++ {
+		}
+		if i == len(pinned) {
+			pinned = append(pinned, config.ToolConfig{Name: mt.Name(), Package: mt.Package})
+		}
+		pinned[i].Version = mt.Version
+		pinned[i].Source = config.ToolSourceGoMod
+	}
+	return pinned
+}
+
+// ProjectTools returns the configured tools pinned by the tool directives of ./go.mod
+func ProjectTools(cfg *config.Config) ([]config.ToolConfig, error) {
+	moduleTools, err := ReadModuleTools("go.mod")
+	if err != nil {
+		return cfg.Tools, err
+	}
+	return ApplyModulePins(cfg.Tools, moduleTools), nil
+}
+
+// Find returns the module tool with the given package path or binary name
+func Find(moduleTools []ModuleTool, nameOrPackage string) (ModuleTool, bool) {
+	for _, mt := range moduleTools {
+		if mt.Package == nameOrPackage || mt.Name() == nameOrPackage {
+			return mt, true
+		}
+	}
+	return ModuleTool{}, false
+}
+
+// Command returns the command line running the named tool with args, which is 'go tool <name>'
+// when the module declares the tool, the binary of LocalBinDir when installed there and the
+// binary on PATH otherwise
+func Command(moduleTools []ModuleTool, name string, args ...string) []string {
+	if _, ok := Find(moduleTools, name); ok {
+		return append([]string{"go", "tool", name}, args...)
+	}
+	if binPath, ok := localBinary(name); ok {
+		return append([]string{binPath}, args...)
+	}
+	return append([]string{name}, args...)
+}
+
+// RemoveTool drops the tool directive of the package or binary name from the go.mod file,
+// returning the package path of the removed tool
+func RemoveTool(goModPath, nameOrPackage string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return strconst.Empty, err
+	}
+
+	mod, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return strconst.Empty, err
+	}
+
+	tool, ok := Find(ModuleTools(mod), nameOrPackage)
+	if !ok {
+		return strconst.Empty, fmt.Errorf("no tool directive for %s in %s", nameOrPackage, goModPath)
+	}
+	if err := mod.DropTool(tool.Package); err != nil {
+		return strconst.Empty, err
+	}
+
+	mod.Cleanup()
+	data, err = mod.Format()
+	if err != nil {
+		return strconst.Empty, err
+	}
+	return tool.Package, osutil.WriteFile(goModPath, data, 0o644)
+}
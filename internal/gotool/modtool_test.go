@@ -0,0 +1,124 @@
+package gotool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/thought2code/godev/internal/config"
+)
+
+const testGoMod = `module example.com/app
+
+go 1.25
+
+tool (
+	example.com/app/cmd/gen
+	github.com/golangci/golangci-lint/v2/cmd/golangci-lint
+	golang.org/x/tools/cmd/goimports
+	mvdan.cc/gofumpt
+)
+
+require (
+	github.com/golangci/golangci-lint/v2 v2.5.0
+	golang.org/x/tools v0.38.0
+	golang.org/x/tools/cmd/goimports v0.39.0
+	mvdan.cc/gofumpt v0.9.2
+)
+`
+
+func TestModuleTools(t *testing.T) {
+	mod, err := modfile.Parse("go.mod", []byte(testGoMod), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ModuleTool{
+		{Package: "example.com/app/cmd/gen", Module: "example.com/app"},
+		{Package: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint", Module: "github.com/golangci/golangci-lint/v2", Version: "v2.5.0"},
+		{Package: "golang.org/x/tools/cmd/goimports", Module: "golang.org/x/tools/cmd/goimports", Version: "v0.39.0"},
+		{Package: "mvdan.cc/gofumpt", Module: "mvdan.cc/gofumpt", Version: "v0.9.2"},
+	}
+	if got := ModuleTools(mod); !reflect.DeepEqual(got, want) {
+		t.Errorf("ModuleTools() failed, got = %v, want = %v", got, want)
+	}
+}
+
+func TestApplyModulePins(t *testing.T) {
+	tools := []config.ToolConfig{
+		{Name: "gofumpt", Package: "mvdan.cc/gofumpt", Version: "v0.8.0", Source: config.ToolSourceDefault},
+		{Name: "goimports", Package: "golang.org/x/tools/cmd/goimports", Version: "v0.30.0", Source: config.ToolSourceConfig},
+	}
+	moduleTools := []ModuleTool{
+		{Package: "mvdan.cc/gofumpt", Module: "mvdan.cc/gofumpt", Version: "v0.9.2"},
+		{Package: "example.com/app/cmd/gen", Module: "example.com/app"},
+		{Package: "honnef.co/go/tools/cmd/staticcheck", Module: "honnef.co/go/tools", Version: "v0.6.1"},
+	}
+
+	want := []config.ToolConfig{
+		{Name: "gofumpt", Package: "mvdan.cc/gofumpt", Version: "v0.9.2", Source: config.ToolSourceGoMod},
+		{Name: "goimports", Package: "golang.org/x/tools/cmd/goimports", Version: "v0.30.0", Source: config.ToolSourceConfig},
+		{Name: "staticcheck", Package: "honnef.co/go/tools/cmd/staticcheck", Version: "v0.6.1", Source: config.ToolSourceGoMod},
+	}
+	got := ApplyModulePins(tools, moduleTools)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyModulePins() failed, got = %v, want = %v", got, want)
+	}
+	if tools[0].Version != "v0.8.0" {
+		t.Errorf("ApplyModulePins() modified its input, got = %v", tools[0])
+	}
+}
+
+func TestCommand(t *testing.T) {
+	moduleTools := []ModuleTool{{Package: "mvdan.cc/gofumpt", Module: "mvdan.cc/gofumpt", Version: "v0.9.2"}}
+
+	tests := []struct {
+		name string
+		tool string
+		want []string
+	}{
+		{name: "declared tool runs with go tool", tool: "gofumpt", want: []string{"go", "tool", "gofumpt", "-w", "."}},
+		{name: "undeclared tool runs from PATH", tool: "goimports", want: []string{"goimports", "-w", "."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Command(moduleTools, tt.tool, "-w", "."); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Command() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveTool(t *testing.T) {
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(goModPath, []byte(testGoMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := RemoveTool(goModPath, "gofumpt")
+	if err != nil {
+		t.Fatalf("RemoveTool() failed, got error = %v", err)
+	}
+	if pkg != "mvdan.cc/gofumpt" {
+		t.Errorf("RemoveTool() failed, got package = %v, want = %v", pkg, "mvdan.cc/gofumpt")
+	}
+
+	moduleTools, err := ReadModuleTools(goModPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Find(moduleTools, "gofumpt"); ok {
+		t.Errorf("RemoveTool() failed, the tool directive is still in go.mod")
+	}
+	if len(moduleTools) != 3 {
+		t.Errorf("RemoveTool() failed, got %d tools left, want = 3", len(moduleTools))
+	}
+
+	if _, err := RemoveTool(goModPath, "gofumpt"); err == nil {
+		t.Errorf("RemoveTool() failed, want error removing an undeclared tool")
+	}
+}
@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// baselineVersion is the format version of the baseline file
+const baselineVersion = 1
+
+// Baseline is the set of accepted findings, the lint only fails on the findings not in it
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"findings"`
+
+	// matched tells which entries matched a finding of the current run, and applied which
+	// steps reported their findings
+	matched []bool
+	applied map[string]bool
+}
+
+// BaselineEntry is an accepted finding, the file, linter and message are kept for the readers
+// of the baseline while the fingerprint is what is matched
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Step        string `json:"step"`
+	File        string `json:"file"`
+	Linter      string `json:"linter"`
+	Message     string `json:"message"`
+}
+
+var (
+	numberPattern     = regexp.MustCompile(`\d+`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint identifies a finding by its file, linter, message and line of code, without the
+// line number so that it survives code added above it. Numbers in the message, like a line
+// length or a complexity, are ignored too
+func Fingerprint(f Finding) string {
+	normalize := func(s string) string {
+		return whitespacePattern.ReplaceAllString(strings.TrimSpace(s), strconst.Space)
+	}
+	message := numberPattern.ReplaceAllString(normalize(f.Message), "N")
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{f.File, f.Linter, message, normalize(f.Source)}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewBaseline accepts all findings of the results
+func NewBaseline(results []Result) *Baseline {
+	b := &Baseline{Version: baselineVersion, Entries: []BaselineEntry{}}
+	for _, result := range results {
+		for _, f := range result.Findings {
+			b.Entries = append(b.Entries, BaselineEntry{Fingerprint: Fingerprint(f), Step: result.Step, File: f.File, Linter: f.Linter, Message: f.Message})
+		}
+	}
+	return b
+}
+
+// ReadBaseline reads the baseline file, nil without error when it does not exist
+func ReadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline %s version %d, expected %d", path, b.Version, baselineVersion)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline to the file, to be committed with the project
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, strconst.Empty, "  ")
+	if err != nil {
+		return err
+	}
+	return osutil.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Apply removes the findings accepted by the baseline from the result. A step failing only
+// because of its findings passes when all of them are accepted. Each entry accepts one finding,
+// so a new copy of an accepted mistake still fails
+func (b *Baseline) Apply(result Result) Result {
+	if b == nil {
+		return result
+	}
+	if b.matched == nil {
+		b.matched, b.applied = make([]bool, len(b.Entries)), make(map[string]bool)
+	}
+	// a step failing without findings could not report them, its entries are not fixed
+	if result.Err == nil || len(result.Findings) > 0 {
+		b.applied[result.Step] = true
+	}
+
+	var kept []Finding
+	for _, f := range result.Findings {
+		fingerprint := Fingerprint(f)
+		i := 0
+		for ; i < len(b.Entries) && (b.matched[i] || b.Entries[i].Fingerprint != fingerprint); i++ {
+		}
+		if i == len(b.Entries) {
+			kept = append(kept, f)
+			continue
+		}
+		b.matched[i] = true
+		result.Baselined++
+	}
+
+	if result.Baselined == 0 {
+		return result
+	}
+	result.Findings, result.Count = kept, len(kept)
+	if len(kept) == 0 && result.Status == StatusFailed {
+		result.Status, result.Err = StatusPassed, nil
+	}
+	return result
+}
+
+// Fixed returns the entries of the steps which reported their findings but not these ones
+func (b *Baseline) Fixed() []BaselineEntry {
+	if b == nil || b.matched == nil {
+		return nil
+	}
+	var fixed []BaselineEntry
+	for i, entry := range b.Entries {
+		if b.applied[entry.Step] && !b.matched[i] {
+			fixed = append(fixed, entry)
+		}
+	}
+	return fixed
+}
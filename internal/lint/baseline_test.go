@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	base := Finding{File: "main.go", Line: 10, Column: 2, Linter: "lll", Severity: SeverityError, Message: "line is 130 characters", Source: "\tfmt.Println(x)"}
+
+	tests := []struct {
+		name string
+		f    Finding
+		same bool
+	}{
+		{name: "moved to another line", f: Finding{File: "main.go", Line: 42, Linter: "lll", Message: "line is 130 characters", Source: "fmt.Println(x)  "}, same: true},
+		{name: "different number in the message", f: Finding{File: "main.go", Linter: "lll", Message: "line is 131 characters", Source: "fmt.Println(x)"}, same: true},
+		{name: "different file", f: Finding{File: "cmd/main.go", Linter: "lll", Message: "line is 130 characters", Source: "fmt.Println(x)"}},
+		{name: "different linter", f: Finding{File: "main.go", Linter: "revive", Message: "line is 130 characters", Source: "fmt.Println(x)"}},
+		{name: "different code", f: Finding{File: "main.go", Linter: "lll", Message: "line is 130 characters", Source: "fmt.Println(y)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fingerprint(tt.f) == Fingerprint(base); got != tt.same {
+				t.Errorf("Fingerprint() failed, got same = %v, want = %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestBaselineApply(t *testing.T) {
+	unchecked := Finding{File: "main.go", Line: 5, Linter: "errcheck", Severity: SeverityError, Message: "Error return value is not checked", Source: "f()"}
+	long := Finding{File: "main.go", Line: 9, Linter: "lll", Severity: SeverityError, Message: "line is 130 characters"}
+	baseline := NewBaseline([]Result{
+		{Step: "golangci-lint", Findings: []Finding{unchecked, long}},
+		{Step: "gofumpt", Findings: []Finding{{File: "a.go", Linter: "gofumpt", Message: "file is not formatted"}}},
+	})
+
+	moved := unchecked
+	moved.Line = 20
+	copied := unchecked
+	copied.Line = 30
+	result := baseline.Apply(Result{Step: "golangci-lint", Status: StatusFailed, Count: 2, Err: errors.New("exit status 1"), Findings: []Finding{moved, copied}})
+
+	// each entry accepts a single finding, the copy of the unchecked error is new
+	if result.Status != StatusFailed || result.Count != 1 || result.Baselined != 1 || result.Findings[0].Line != 30 {
+		t.Errorf("Apply() failed, got = %+v", result)
+	}
+	// the gofumpt step did not report its findings, so its entry is not fixed
+	if fixed := baseline.Fixed(); len(fixed) != 1 || fixed[0].Linter != "lll" {
+		t.Errorf("Fixed() failed, got = %+v, want the lll entry", fixed)
+	}
+
+	baseline = NewBaseline([]Result{{Step: "golangci-lint", Findings: []Finding{unchecked}}})
+	result = baseline.Apply(Result{Step: "golangci-lint", Status: StatusFailed, Count: 1, Err: errors.New("exit status 1"), Findings: []Finding{moved}})
+	if result.Status != StatusPassed || result.Err != nil || result.Count != 0 || len(baseline.Fixed()) != 0 {
+		t.Errorf("Apply() failed, got = %+v, want a passed step", result)
+	}
+}
+
+func TestReadBaseline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lint-baseline.json")
+
+	got, err := ReadBaseline(path)
+	if err != nil || got != nil {
+		t.Fatalf("ReadBaseline() failed, got = %v, %v, want nil without error", got, err)
+	}
+
+	want := NewBaseline([]Result{{Step: "golangci-lint", Findings: []Finding{{File: "main.go", Linter: "lll", Message: "line is 130 characters"}}}})
+	if err := want.Write(path); err != nil {
+		t.Fatalf("Write() failed, got unexpected error = %v", err)
+	}
+	got, err = ReadBaseline(path)
+	if err != nil {
+		t.Fatalf("ReadBaseline() failed, got unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Entries, want.Entries) {
+		t.Errorf("ReadBaseline() failed, got = %+v, want = %+v", got.Entries, want.Entries)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 2, "findings": []}`), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if _, err := ReadBaseline(path); err == nil {
+		t.Errorf("ReadBaseline() failed, got no error for an unsupported version")
+	}
+}
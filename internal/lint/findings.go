@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// severities of a Finding, golangci-lint may report others when its configuration sets them
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Finding is one problem reported by a lint step
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Linter   string `json:"linter"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Source is the reported line of code, when the linter tells it
+	Source string `json:"source,omitempty"`
+}
+
+// golangciReport is the part of the golangci-lint JSON output godev reads
+type golangciReport struct {
+	Issues []struct {
+		FromLinter  string
+		Text        string
+		Severity    string
+		SourceLines []string
+		Pos         struct {
+			Filename string
+			Line     int
+			Column   int
+		}
+	}
+}
+
+// ParseGolangciJSON reads the findings from the output of 'golangci-lint run --output.json.path stdout',
+// the JSON report is a single line which may follow the text output configured by the project
+func ParseGolangciJSON(output string) ([]Finding, error) {
+	for _, line := range strings.Split(output, strconst.NewLine) {
+		if !strings.HasPrefix(line, `{"Issues":`) {
+			continue
+		}
+
+		var report golangciReport
+		if err := json.Unmarshal([]byte(line), &report); err != nil {
+			return nil, fmt.Errorf("invalid golangci-lint JSON output: %w", err)
+		}
+		findings := make([]Finding, 0, len(report.Issues))
+		for _, issue := range report.Issues {
+			severity := strings.ToLower(issue.Severity)
+			if severity == strconst.Empty {
+				severity = SeverityError
+			}
+			finding := Finding{
+				File:     issue.Pos.Filename,
+				Line:     issue.Pos.Line,
+				Column:   issue.Pos.Column,
+				Linter:   issue.FromLinter,
+				Severity: severity,
+				Message:  issue.Text,
+			}
+			if len(issue.SourceLines) > 0 {
+				finding.Source = strings.TrimSpace(issue.SourceLines[0])
+			}
+			findings = append(findings, finding)
+		}
+		return findings, nil
+	}
+	return nil, fmt.Errorf("no golangci-lint JSON report in the output")
+}
+
+// diffFindings returns a parser reporting each file of the diff printed by a formatter with -d
+// or by 'go mod tidy -diff', e.g. "diff -u main.go.orig main.go" or "diff current/go.mod tidy/go.mod"
+func diffFindings(linter, message string) func(output string) ([]Finding, error) {
+	return func(output string) ([]Finding, error) {
+		var findings []Finding
+		for _, line := range strings.Split(output, strconst.NewLine) {
+			// the lines of the diff itself start with a space, + or -
+			if !strings.HasPrefix(line, "diff ") {
+				continue
+			}
+			fields := strings.Fields(line)
+			file := strings.TrimPrefix(fields[len(fields)-1], "tidy/")
+			findings = append(findings, Finding{File: file, Linter: linter, Severity: SeverityError, Message: message})
+		}
+		return findings, nil
+	}
+}
@@ -0,0 +1,88 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGolangciJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    []Finding
+		wantErr bool
+	}{
+		{
+			name:   "no issues",
+			output: `{"Issues":[],"Report":{"Linters":[{"Name":"errcheck","Enabled":true}]}}` + "\n",
+			want:   []Finding{},
+		},
+		{
+			name: "issues after the configured text output",
+			output: "main.go:5:2: Error return value is not checked (errcheck)\n" +
+				`{"Issues":[{"FromLinter":"errcheck","Text":"Error return value is not checked","Severity":"","SourceLines":["\tf()"],"Pos":{"Filename":"main.go","Offset":40,"Line":5,"Column":2}},` +
+				`{"FromLinter":"lll","Text":"line is 130 characters","Severity":"Warning","Pos":{"Filename":"cmd/root.go","Line":12,"Column":0}}]}` + "\n",
+			want: []Finding{
+				{File: "main.go", Line: 5, Column: 2, Linter: "errcheck", Severity: SeverityError, Message: "Error return value is not checked", Source: "f()"},
+				{File: "cmd/root.go", Line: 12, Linter: "lll", Severity: SeverityWarning, Message: "line is 130 characters"},
+			},
+		},
+		{
+			name:    "no report",
+			output:  "Error: can't load config: unsupported version\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := ParseGolangciJSON(tt.output)
+			if (gotErr != nil) != tt.wantErr {
+				t.Fatalf("ParseGolangciJSON() failed, got unexpected error = %v", gotErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGolangciJSON() failed, got = %+v, want = %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffFindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		linter string
+		output string
+		want   []Finding
+	}{
+		{
+			name:   "formatter diffs",
+			linter: "gofumpt",
+			output: "diff -u cmd/a.go.orig cmd/a.go\n--- cmd/a.go.orig\n+++ cmd/a.go\n@@ -1,2 +1,2 @@\n diff := 1\n-x\ndiff -u b.go.orig b.go\n",
+			want: []Finding{
+				{File: "cmd/a.go", Linter: "gofumpt", Severity: SeverityError, Message: "not formatted"},
+				{File: "b.go", Linter: "gofumpt", Severity: SeverityError, Message: "not formatted"},
+			},
+		},
+		{
+			name:   "tidy diff",
+			linter: "tidy",
+			output: "diff current/go.mod tidy/go.mod\n--- current/go.mod\n+++ tidy/go.mod\n",
+			want:   []Finding{{File: "go.mod", Linter: "tidy", Severity: SeverityError, Message: "not formatted"}},
+		},
+		{
+			name:   "no diff",
+			linter: "gofumpt",
+			output: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := diffFindings(tt.linter, "not formatted")(tt.output)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffFindings() failed, got = %+v, want = %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thought2code/godev/internal/config"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// statuses of a step Result
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// ReasonNoCheckCommand skips a custom step without check command in check mode, as its run
+// command may write files
+const ReasonNoCheckCommand = "no check command"
+
+// Step is one command of the lint pipeline
+type Step struct {
+	Name    string
+	Command []string
+	// Parse reads the findings from the standard output of the command, nil for none
+	Parse func(output string) ([]Finding, error)
+	// Report tells the standard output of the command is a machine readable report, shown
+	// through the parsed findings only
+	Report bool
+	// Count returns the number of files rewritten by the command when Parse is nil
+	Count func(output string) int
+	// FailOnFindings fails the step when findings are reported even if the command succeeds,
+	// like formatters printing diffs in check mode
+	FailOnFindings bool
+	// SkipReason tells why a step without command is skipped, empty when there is nothing to do
+	SkipReason string
+}
+
+// Result is the outcome of a step
+type Result struct {
+	Step     string
+	Status   string
+	Duration time.Duration
+	// Count is the number of findings, or of rewritten files for the formatters
+	Count    int
+	Findings []Finding
+	// Baselined is the number of findings accepted by the baseline, not in Findings
+	Baselined int
+	// Reason tells why a skipped step did not run, like ReasonNoCheckCommand
+	Reason string
+	Err    error
+}
+
+// Runner runs the command of a step, writing its standard output to stdout
+type Runner func(ctx context.Context, step Step, stdout io.Writer) error
+
+// Options selects the commands of the steps
+type Options struct {
+	// Check runs the commands of 'godev lint --check', which never write files
+	Check bool
+	// Changed limits the formatters to Files and golangci-lint to the issues in Packages
+	// introduced since the git revision NewFromRev
+	Changed    bool
+	Files      []string
+	Packages   []string
+	NewFromRev string
+	// GolangciConfig replaces the golangci-lint configuration of the project when set
+	GolangciConfig string
+}
+
+// Steps returns the configured pipeline, a step without command is skipped when there is
+// nothing for it to do
+func Steps(cfg config.LintConfig, opts Options) []Step {
+	steps := make([]Step, 0, len(cfg.Steps))
+	for _, name := range cfg.Steps {
+		steps = append(steps, newStep(cfg, name, opts))
+	}
+	return steps
+}
+
+func newStep(cfg config.LintConfig, name string, opts Options) Step {
+	switch name {
+	case config.LintStepGoimports, config.LintStepGofumpt:
+		targets := []string{"."}
+		if opts.Changed {
+			if len(opts.Files) == 0 {
+				return Step{Name: name}
+			}
+			targets = opts.Files
+		}
+		// -l lists the rewritten files, -d prints the diffs without writing them
+		if opts.Check {
+			return Step{Name: name, Command: append([]string{name, "-d"}, targets...), Parse: diffFindings(name, "file is not formatted"), FailOnFindings: true}
+		}
+		return Step{Name: name, Command: append([]string{name, "-l", "-w"}, targets...), Count: CountLines}
+	case config.LintStepGolangciLint:
+		command := []string{"golangci-lint", "run", "--output.json.path=stdout", "--show-stats=false"}
+		if opts.GolangciConfig != strconst.Empty {
+			command = append(command, "--config="+opts.GolangciConfig)
+		}
+		if !opts.Changed {
+			return Step{Name: name, Command: append(command, "./..."), Parse: ParseGolangciJSON, Report: true}
+		}
+		if len(opts.Packages) == 0 {
+			return Step{Name: name}
+		}
+		command = append(command, "--new-from-rev="+opts.NewFromRev)
+		return Step{Name: name, Command: append(command, opts.Packages...), Parse: ParseGolangciJSON, Report: true}
+	case config.LintStepTidy:
+		if opts.Check {
+			return Step{Name: name, Command: []string{"go", "mod", "tidy", "-diff"}, Parse: diffFindings(name, "file is not tidy"), FailOnFindings: true}
+		}
+		return Step{Name: name, Command: []string{"go", "mod", "tidy"}}
+	}
+
+	for _, command := range cfg.Commands {
+		if command.Name != name {
+			continue
+		}
+		if opts.Check {
+			if len(command.Check) == 0 {
+				return Step{Name: name, SkipReason: ReasonNoCheckCommand}
+			}
+			return Step{Name: name, Command: command.Check}
+		}
+		return Step{Name: name, Command: command.Run}
+	}
+	// rejected by the validation of the configuration
+	return Step{Name: name}
+}
+
+// Run runs the steps in order, all of them unless failFast stops at the first failure, calling
+// onResult after each step. The findings accepted by the baseline, which may be nil, are removed
+func Run(ctx context.Context, steps []Step, failFast bool, baseline *Baseline, run Runner, onResult func(Result)) []Result {
+	results := make([]Result, 0, len(steps))
+	stop := false
+	for _, step := range steps {
+		result := Result{Step: step.Name, Status: StatusSkipped, Reason: step.SkipReason}
+		if !stop && ctx.Err() == nil && len(step.Command) > 0 {
+			result = runStep(ctx, step, run)
+			// only the parsed findings are known to the baseline
+			if step.Parse != nil {
+				result = baseline.Apply(result)
+			}
+		}
+		stop = stop || (failFast && result.Status == StatusFailed)
+
+		results = append(results, result)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+	return results
+}
+
+func runStep(ctx context.Context, step Step, run Runner) Result {
+	var stdout bytes.Buffer
+	start := time.Now()
+	err := run(ctx, step, &stdout)
+	result := Result{Step: step.Name, Status: StatusPassed, Duration: time.Since(start), Err: err}
+
+	switch {
+	case step.Parse != nil:
+		findings, parseErr := step.Parse(stdout.String())
+		// a failed command may print no report at all, its own error tells more
+		if parseErr != nil && result.Err == nil {
+			result.Err = parseErr
+		}
+		result.Findings, result.Count = findings, len(findings)
+	case step.Count != nil:
+		result.Count = step.Count(stdout.String())
+	}
+	if result.Err != nil || (step.FailOnFindings && result.Count > 0) {
+		result.Status = StatusFailed
+	}
+	return result
+}
+
+// Failed tells whether any step failed
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// CountLines counts the non-empty lines, like the files listed by gofumpt -l
+func CountLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, strconst.NewLine) {
+		if strings.TrimSpace(line) != strconst.Empty {
+			count++
+		}
+	}
+	return count
+}
+
+// PackagePatterns returns the relative package patterns of the directories of the files,
+// e.g. ./internal/lint for internal/lint/pipeline.go
+func PackagePatterns(files []string) []string {
+	var patterns []string
+	for _, file := range files {
+		pattern := "./" + filepath.ToSlash(filepath.Dir(file))
+		if pattern == "./." {
+			pattern = "."
+		}
+		if !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/thought2code/godev/internal/config"
+)
+
+func TestSteps(t *testing.T) {
+	cfg := config.LintConfig{
+		Steps: []string{config.LintStepGofumpt, config.LintStepTidy, "vet", "license"},
+		Commands: []config.LintCommand{
+			{Name: "vet", Run: []string{"go", "vet", "./..."}},
+			{Name: "license", Run: []string{"addlicense", "."}, Check: []string{"addlicense", "-check", "."}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.LintConfig
+		opts Options
+		want [][]string
+	}{
+		{
+			name: "write mode",
+			cfg:  cfg,
+			want: [][]string{{"gofumpt", "-l", "-w", "."}, {"go", "mod", "tidy"}, {"go", "vet", "./..."}, {"addlicense", "."}},
+		},
+		{
+			name: "check mode never writes",
+			cfg:  cfg,
+			opts: Options{Check: true},
+			want: [][]string{{"gofumpt", "-d", "."}, {"go", "mod", "tidy", "-diff"}, nil, {"addlicense", "-check", "."}},
+		},
+		{
+			name: "changed files",
+			cfg:  config.LintConfig{Steps: config.LintSteps},
+			opts: Options{Changed: true, Files: []string{"main.go", "cmd/lint.go"}, Packages: []string{".", "./cmd"}, NewFromRev: "abc123"},
+			want: [][]string{
+				{"goimports", "-l", "-w", "main.go", "cmd/lint.go"},
+				{"gofumpt", "-l", "-w", "main.go", "cmd/lint.go"},
+				{"golangci-lint", "run", "--output.json.path=stdout", "--show-stats=false", "--new-from-rev=abc123", ".", "./cmd"},
+				{"go", "mod", "tidy"},
+			},
+		},
+		{
+			name: "no changed go files",
+			cfg:  config.LintConfig{Steps: config.LintSteps},
+			opts: Options{Check: true, Changed: true, NewFromRev: "abc123"},
+			want: [][]string{nil, nil, nil, {"go", "mod", "tidy", "-diff"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, step := range Steps(tt.cfg, tt.opts) {
+				got = append(got, step.Command)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Steps() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	steps := []Step{
+		{Name: "gofumpt", Command: []string{"gofumpt"}, Parse: diffFindings("gofumpt", "file is not formatted"), FailOnFindings: true},
+		{Name: "golangci-lint", Command: []string{"golangci-lint"}, Parse: ParseGolangciJSON, Report: true},
+		{Name: "tidy", Command: []string{"tidy"}},
+		{Name: "goimports"},
+		{Name: "vet", SkipReason: ReasonNoCheckCommand},
+	}
+	outputs := map[string]string{
+		"gofumpt":       "diff a.go.orig a.go\n--- a.go.orig\n+++ a.go\n",
+		"golangci-lint": `{"Issues":[{"FromLinter":"revive","Text":"exported function Foo should have comment","Pos":{"Filename":"main.go","Line":3,"Column":1}},{"FromLinter":"lll","Text":"line is too long","Pos":{"Filename":"main.go","Line":9}}]}` + "\n",
+	}
+	run := func(ctx context.Context, step Step, stdout io.Writer) error {
+		_, _ = io.WriteString(stdout, outputs[step.Name])
+		if step.Name == "golangci-lint" {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name         string
+		failFast     bool
+		wantStatus   []string
+		wantFindings []int
+	}{
+		{
+			name:         "continue on error",
+			wantStatus:   []string{StatusFailed, StatusFailed, StatusPassed, StatusSkipped, StatusSkipped},
+			wantFindings: []int{1, 2, 0, 0, 0},
+		},
+		{
+			name:         "fail fast",
+			failFast:     true,
+			wantStatus:   []string{StatusFailed, StatusSkipped, StatusSkipped, StatusSkipped, StatusSkipped},
+			wantFindings: []int{1, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []string
+			results := Run(context.Background(), steps, tt.failFast, nil, run, func(r Result) { reported = append(reported, r.Step) })
+
+			var gotStatus []string
+			var gotFindings []int
+			for _, result := range results {
+				gotStatus = append(gotStatus, result.Status)
+				gotFindings = append(gotFindings, result.Count)
+			}
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) || !reflect.DeepEqual(gotFindings, tt.wantFindings) {
+				t.Errorf("Run() failed, got status = %v, findings = %v, want = %v, %v", gotStatus, gotFindings, tt.wantStatus, tt.wantFindings)
+			}
+			if got := results[len(results)-1].Reason; got != ReasonNoCheckCommand {
+				t.Errorf("Run() failed, got reason = %q, want = %q", got, ReasonNoCheckCommand)
+			}
+			if len(reported) != len(steps) {
+				t.Errorf("Run() failed, reported %d results, want = %d", len(reported), len(steps))
+			}
+			if !Failed(results) {
+				t.Errorf("Failed() failed, got = false, want = true")
+			}
+		})
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	if got := CountLines("a.go\n\nb/c.go\n"); got != 2 {
+		t.Errorf("CountLines() failed, got = %v, want = 2", got)
+	}
+}
+
+func TestPackagePatterns(t *testing.T) {
+	got := PackagePatterns([]string{"main.go", "internal/lint/pipeline.go", "internal/lint/pipeline_test.go", "cmd/lint.go"})
+	want := []string{".", "./internal/lint", "./cmd"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PackagePatterns() failed, got = %v, want = %v", got, want)
+	}
+}
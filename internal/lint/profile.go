@@ -0,0 +1,293 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// names of the lint profiles
+const (
+	ProfileMinimal  = "minimal"
+	ProfileStandard = "standard"
+	ProfileStrict   = "strict"
+	ProfileSecurity = "security"
+)
+
+// DefaultProfile is written by 'godev init' unless another one is chosen
+const DefaultProfile = ProfileStandard
+
+// Profile is a curated golangci-lint linters section. A profile gets a new revision whenever
+// its linters or settings change, so projects can upgrade to it
+type Profile struct {
+	Name        string
+	Revision    int
+	Description string
+	Linters     []string
+	// Settings are the linters.settings by linter
+	Settings map[string]map[string]any
+	Rules    []ExclusionRule
+}
+
+// ExclusionRule is an entry of linters.exclusions.rules
+type ExclusionRule struct {
+	Path    string   `yaml:"path,omitempty"`
+	Linters []string `yaml:"linters,omitempty,flow"`
+	Text    string   `yaml:"text,omitempty"`
+}
+
+// staticcheck checks disabled by the standard profile, the style ones are too noisy for most projects
+var standardStaticcheckChecks = []any{"all", "-ST1000", "-ST1003", "-ST1016", "-ST1020", "-ST1021", "-ST1022", "-QF1001", "-QF1008"}
+
+// profileHistory holds every revision of the profiles, oldest first, to upgrade from any of them
+var profileHistory = map[string][]Profile{
+	ProfileMinimal: {
+		{
+			Name:        ProfileMinimal,
+			Revision:    1,
+			Description: "Bugs only: unchecked errors, suspicious constructs and dead code",
+			Linters:     []string{"errcheck", "govet", "ineffassign", "unused"},
+		},
+	},
+	ProfileStandard: {
+		{
+			// the configuration written by 'godev init' before the profiles existed
+			Name:        ProfileStandard,
+			Revision:    1,
+			Description: "staticcheck without its style checks",
+			Linters:     []string{"staticcheck"},
+			Rules: []ExclusionRule{
+				{Linters: []string{"staticcheck"}, Text: "ST1000:"},
+				{Linters: []string{"staticcheck"}, Text: "ST1003:"},
+				{Linters: []string{"staticcheck"}, Text: "ST1016:"},
+				{Linters: []string{"staticcheck"}, Text: "ST1020:"},
+				{Linters: []string{"staticcheck"}, Text: "ST1021:"},
+				{Linters: []string{"staticcheck"}, Text: "ST1022:"},
+				{Linters: []string{"staticcheck"}, Text: "QF1001:"},
+				{Linters: []string{"staticcheck"}, Text: "QF1008:"},
+			},
+		},
+		{
+			Name:        ProfileStandard,
+			Revision:    2,
+			Description: "The minimal profile with staticcheck, misspellings and needless conversions",
+			Linters:     []string{"errcheck", "govet", "ineffassign", "misspell", "staticcheck", "unconvert", "unused"},
+			Settings: map[string]map[string]any{
+				"staticcheck": {"checks": standardStaticcheckChecks},
+			},
+		},
+	},
+	ProfileStrict: {
+		{
+			Name:        ProfileStrict,
+			Revision:    1,
+			Description: "The standard profile with style, complexity and error wrapping checks",
+			Linters: []string{
+				"bodyclose", "copyloopvar", "errcheck", "errorlint", "gocritic", "gocyclo", "govet", "ineffassign",
+				"misspell", "nilerr", "prealloc", "revive", "staticcheck", "unconvert", "unparam", "unused",
+			},
+			Settings: map[string]map[string]any{
+				"gocyclo":     {"min-complexity": 15},
+				"staticcheck": {"checks": []any{"all", "-ST1000"}},
+			},
+			Rules: []ExclusionRule{
+				{Path: `_test\.go`, Linters: []string{"gocyclo", "unparam"}},
+			},
+		},
+	},
+	ProfileSecurity: {
+		{
+			Name:        ProfileSecurity,
+			Revision:    1,
+			Description: "The standard profile with gosec and checks of HTTP bodies, contexts and SQL rows",
+			Linters: []string{
+				"bodyclose", "errcheck", "errorlint", "gosec", "govet", "ineffassign", "misspell", "noctx",
+				"rowserrcheck", "sqlclosecheck", "staticcheck", "unconvert", "unused",
+			},
+			Settings: map[string]map[string]any{
+				"gosec":       {"severity": "medium", "confidence": "medium"},
+				"staticcheck": {"checks": standardStaticcheckChecks},
+			},
+			Rules: []ExclusionRule{
+				{Path: `_test\.go`, Linters: []string{"gosec", "noctx"}},
+			},
+		},
+	},
+}
+
+// Profiles returns the latest revision of every profile, in order of strictness
+func Profiles() []Profile {
+	names := []string{ProfileMinimal, ProfileStandard, ProfileStrict, ProfileSecurity}
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		history := profileHistory[name]
+		profiles = append(profiles, history[len(history)-1])
+	}
+	return profiles
+}
+
+// FindProfile returns the latest revision of the named profile
+func FindProfile(name string) (Profile, error) {
+	history, ok := profileHistory[name]
+	if !ok {
+		names := make([]string, 0, len(profileHistory))
+		for _, p := range Profiles() {
+			names = append(names, p.Name)
+		}
+		return Profile{}, fmt.Errorf("unknown lint profile %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return history[len(history)-1], nil
+}
+
+func findRevision(name string, revision int) (Profile, bool) {
+	for _, p := range profileHistory[name] {
+		if p.Revision == revision {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// markerPattern matches the first line of a .golangci.yml written from a profile
+var markerPattern = regexp.MustCompile(`^# godev lint profile: (\S+) \(revision (\d+)\)`)
+
+// Marker is the comment on the first line of the configuration telling the profile it comes from
+func (p Profile) Marker() string {
+	return fmt.Sprintf("# godev lint profile: %s (revision %d), upgrade with 'godev lint config upgrade'", p.Name, p.Revision)
+}
+
+// ParseMarker reads the profile and revision of the configuration, false without marker
+func ParseMarker(data []byte) (name string, revision int, ok bool) {
+	line, _, _ := strings.Cut(string(data), strconst.NewLine)
+	match := markerPattern.FindStringSubmatch(line)
+	if match == nil {
+		return strconst.Empty, 0, false
+	}
+	revision, _ = strconv.Atoi(match[2])
+	return match[1], revision, true
+}
+
+// linters is the linters section of the golangci-lint v2 configuration
+type linters struct {
+	Default    string                    `yaml:"default"`
+	Enable     []string                  `yaml:"enable"`
+	Settings   map[string]map[string]any `yaml:"settings,omitempty"`
+	Exclusions *exclusions               `yaml:"exclusions,omitempty"`
+}
+
+type exclusions struct {
+	Rules []ExclusionRule `yaml:"rules"`
+}
+
+func (p Profile) linters() linters {
+	section := linters{Default: "none", Enable: p.Linters, Settings: p.Settings}
+	if len(p.Rules) > 0 {
+		section.Exclusions = &exclusions{Rules: p.Rules}
+	}
+	return section
+}
+
+// RenderLinters renders the linters section of the .golangci.yml of the profile
+func (p Profile) RenderLinters() (string, error) {
+	return encodeYAML(map[string]linters{"linters": p.linters()})
+}
+
+func encodeYAML(v any) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return strconst.Empty, err
+	}
+	if err := encoder.Close(); err != nil {
+		return strconst.Empty, err
+	}
+	return buf.String(), nil
+}
+
+// ApplyProfile replaces the linters section of the configuration with the one of the profile,
+// keeping the formatters and other settings of the project. The paths are made relative to
+// the module root, the result being written out of the project
+func ApplyProfile(data []byte, p Profile) ([]byte, error) {
+	doc, err := parseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+	// the exclusions of older configurations would be left behind otherwise
+	migrateV1Keys(root)
+
+	var section yaml.Node
+	if err := section.Encode(p.linters()); err != nil {
+		return nil, err
+	}
+	setKey(root, "linters", &section)
+
+	run := mappingValue(root, "run")
+	if run == nil {
+		run = &yaml.Node{Kind: yaml.MappingNode}
+		setKey(root, "run", run)
+	}
+	setKey(run, "relative-path-mode", &yaml.Node{Kind: yaml.ScalarNode, Value: "gomod"})
+
+	out, err := encodeYAML(doc)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// parseConfig parses a golangci-lint configuration into a document with a mapping, which is
+// empty for an empty configuration
+func parseConfig(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid golangci-lint configuration: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid golangci-lint configuration: not a mapping")
+	}
+	return &doc, nil
+}
+
+// mappingValue returns the value of the key in the mapping node, nil when missing
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setKey sets the value of the key in the mapping node, keeping the comments of the replaced value
+func setKey(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			old := node.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+func deleteKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = slices.Delete(node.Content, i, i+2)
+			return
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package lint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// legacyConfig is the .golangci.yml written by 'godev init' before the profiles, with local edits
+const legacyConfig = `version: "2"
+
+formatters:
+  enable:
+    - gofumpt
+
+linters:
+  disable-all: true
+  enable:
+    - staticcheck
+    - gosec # audited by the security team
+
+issues:
+  exclude-rules:
+    - linters: [staticcheck]
+      text: "ST1000:"
+    - linters: [staticcheck]
+      text: "ST1003:"
+    - linters: [staticcheck]
+      text: "ST1016:"
+    - linters: [staticcheck]
+      text: "ST1020:"
+    - linters: [staticcheck]
+      text: "ST1021:"
+    - linters: [staticcheck]
+      text: "ST1022:"
+    - linters: [staticcheck]
+      text: "QF1001:"
+    - linters: [staticcheck]
+      text: "QF1008:"
+    - path: gen/
+      linters: [staticcheck]
+`
+
+// golangciConfig is the part of the configuration checked by the tests
+type golangciConfig struct {
+	Formatters struct {
+		Enable []string `yaml:"enable"`
+	} `yaml:"formatters"`
+	Linters struct {
+		Default    string                    `yaml:"default"`
+		Enable     []string                  `yaml:"enable"`
+		Settings   map[string]map[string]any `yaml:"settings"`
+		Exclusions struct {
+			Rules []ExclusionRule `yaml:"rules"`
+		} `yaml:"exclusions"`
+	} `yaml:"linters"`
+	Issues map[string]any `yaml:"issues"`
+	Run    map[string]any `yaml:"run"`
+}
+
+func parseGolangciConfig(t *testing.T, data []byte) golangciConfig {
+	t.Helper()
+	var cfg golangciConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("invalid configuration = %v\n%s", err, data)
+	}
+	return cfg
+}
+
+func TestProfiles(t *testing.T) {
+	for _, p := range Profiles() {
+		t.Run(p.Name, func(t *testing.T) {
+			rendered, err := p.RenderLinters()
+			if err != nil {
+				t.Fatalf("RenderLinters() failed, got unexpected error = %v", err)
+			}
+			cfg := parseGolangciConfig(t, []byte(p.Marker()+"\n"+rendered))
+			if cfg.Linters.Default != "none" || !reflect.DeepEqual(cfg.Linters.Enable, p.Linters) {
+				t.Errorf("RenderLinters() failed, got = %s", rendered)
+			}
+
+			name, revision, ok := ParseMarker([]byte(p.Marker() + "\n" + rendered))
+			if !ok || name != p.Name || revision != p.Revision {
+				t.Errorf("ParseMarker() failed, got = %v, %v, %v, want = %v, %v", name, revision, ok, p.Name, p.Revision)
+			}
+		})
+	}
+
+	if _, err := FindProfile("paranoid"); err == nil {
+		t.Errorf("FindProfile() failed, got no error for an unknown profile")
+	}
+}
+
+func TestUpgradeConfig(t *testing.T) {
+	standard, _ := FindProfile(ProfileStandard)
+	strict, _ := FindProfile(ProfileStrict)
+
+	t.Run("legacy configuration to standard", func(t *testing.T) {
+		out, upgrade, err := UpgradeConfig([]byte(legacyConfig), "")
+		if err != nil {
+			t.Fatalf("UpgradeConfig() failed, got unexpected error = %v", err)
+		}
+		if !strings.HasPrefix(string(out), standard.Marker()+"\n") {
+			t.Errorf("UpgradeConfig() failed, got no marker of the standard profile:\n%s", out)
+		}
+		if !strings.Contains(string(out), "gosec # audited by the security team") {
+			t.Errorf("UpgradeConfig() failed, lost the comment of a kept linter:\n%s", out)
+		}
+
+		cfg := parseGolangciConfig(t, out)
+		wantEnable := []string{"staticcheck", "gosec", "errcheck", "govet", "ineffassign", "misspell", "unconvert", "unused"}
+		if cfg.Linters.Default != "none" || !reflect.DeepEqual(cfg.Linters.Enable, wantEnable) {
+			t.Errorf("UpgradeConfig() failed, got linters = %+v", cfg.Linters)
+		}
+		// the exclusions of the old revision are replaced by the staticcheck settings
+		wantRules := []ExclusionRule{{Path: "gen/", Linters: []string{"staticcheck"}}}
+		if !reflect.DeepEqual(cfg.Linters.Exclusions.Rules, wantRules) || cfg.Issues != nil {
+			t.Errorf("UpgradeConfig() failed, got rules = %+v, issues = %v", cfg.Linters.Exclusions.Rules, cfg.Issues)
+		}
+		if _, ok := cfg.Linters.Settings["staticcheck"]; !ok || !reflect.DeepEqual(cfg.Formatters.Enable, []string{"gofumpt"}) {
+			t.Errorf("UpgradeConfig() failed, got = %s", out)
+		}
+		if !reflect.DeepEqual(upgrade.Added, []string{"errcheck", "govet", "ineffassign", "misspell", "unconvert", "unused"}) || len(upgrade.Kept) != 2 {
+			t.Errorf("UpgradeConfig() failed, got upgrade = %+v", upgrade)
+		}
+
+		// upgrading again changes nothing
+		if _, again, err := UpgradeConfig(out, ""); err != nil || !again.UpToDate() {
+			t.Errorf("UpgradeConfig() failed, got = %+v, %v, want up to date", again, err)
+		}
+	})
+
+	t.Run("standard to strict keeps edited settings", func(t *testing.T) {
+		// the project disabled unconvert and set up gocyclo
+		rendered, _ := standard.RenderLinters()
+		rendered = strings.Replace(rendered, "    - unconvert\n", "", 1)
+		rendered = strings.Replace(rendered, "  settings:\n", "  settings:\n    gocyclo:\n      min-complexity: 30\n", 1)
+		data := standard.Marker() + "\n" + rendered
+
+		out, upgrade, err := UpgradeConfig([]byte(data), ProfileStrict)
+		if err != nil {
+			t.Fatalf("UpgradeConfig() failed, got unexpected error = %v", err)
+		}
+		cfg := parseGolangciConfig(t, out)
+		if got := cfg.Linters.Settings["gocyclo"]["min-complexity"]; got != 30 {
+			t.Errorf("UpgradeConfig() failed, got gocyclo settings = %v, want the local ones", got)
+		}
+		if !reflect.DeepEqual(cfg.Linters.Settings["staticcheck"], map[string]any{"checks": []any{"all", "-ST1000"}}) {
+			t.Errorf("UpgradeConfig() failed, got staticcheck settings = %v, want the strict ones", cfg.Linters.Settings["staticcheck"])
+		}
+		for _, linter := range strict.Linters {
+			if enabled := strings.Contains(strings.Join(cfg.Linters.Enable, ","), linter); enabled == (linter == "unconvert") {
+				t.Errorf("UpgradeConfig() failed, got linter %s enabled = %v", linter, enabled)
+			}
+		}
+		if !reflect.DeepEqual(cfg.Linters.Exclusions.Rules, strict.Rules) {
+			t.Errorf("UpgradeConfig() failed, got rules = %+v", cfg.Linters.Exclusions.Rules)
+		}
+		if !strings.HasPrefix(string(out), strict.Marker()) || len(upgrade.Kept) != 2 {
+			t.Errorf("UpgradeConfig() failed, got upgrade = %+v\n%s", upgrade, out)
+		}
+	})
+}
+
+func TestApplyProfile(t *testing.T) {
+	security, _ := FindProfile(ProfileSecurity)
+	out, err := ApplyProfile([]byte(legacyConfig), security)
+	if err != nil {
+		t.Fatalf("ApplyProfile() failed, got unexpected error = %v", err)
+	}
+
+	cfg := parseGolangciConfig(t, out)
+	if !reflect.DeepEqual(cfg.Linters.Enable, security.Linters) || !reflect.DeepEqual(cfg.Linters.Exclusions.Rules, security.Rules) {
+		t.Errorf("ApplyProfile() failed, got linters = %+v", cfg.Linters)
+	}
+	if cfg.Issues != nil || cfg.Run["relative-path-mode"] != "gomod" || !reflect.DeepEqual(cfg.Formatters.Enable, []string{"gofumpt"}) {
+		t.Errorf("ApplyProfile() failed, got = %s", out)
+	}
+
+	if out, err = ApplyProfile(nil, security); err != nil || !strings.Contains(string(out), "gosec") {
+		t.Errorf("ApplyProfile() failed, got = %s, %v for an empty configuration", out, err)
+	}
+}
@@ -0,0 +1,273 @@
+package lint
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// output formats of the lint report
+const (
+	FormatText       = "text"
+	FormatJSON       = "json"
+	FormatCheckstyle = "checkstyle"
+	FormatJUnit      = "junit"
+	FormatGitHub     = "github"
+)
+
+var Formats = []string{FormatText, FormatJSON, FormatCheckstyle, FormatJUnit, FormatGitHub}
+
+// AllFindings returns the findings of all steps sorted by file and position
+func AllFindings(results []Result) []Finding {
+	var findings []Finding
+	for _, result := range results {
+		findings = append(findings, result.Findings...)
+	}
+	sortFindings(findings)
+	return findings
+}
+
+func sortFindings(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+}
+
+// GroupByFile groups the findings by file, sorted by file and position
+func GroupByFile(findings []Finding) [][]Finding {
+	findings = slices.Clone(findings)
+	sortFindings(findings)
+
+	var groups [][]Finding
+	for i, finding := range findings {
+		if i == 0 || finding.File != findings[i-1].File {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], finding)
+	}
+	return groups
+}
+
+// WriteReport writes the results in one of the machine readable formats
+func WriteReport(w io.Writer, format string, results []Result) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, results)
+	case FormatCheckstyle:
+		return writeCheckstyle(w, results)
+	case FormatJUnit:
+		return writeJUnit(w, results)
+	case FormatGitHub:
+		return writeGitHub(w, results)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	type step struct {
+		Name      string `json:"name"`
+		Status    string `json:"status"`
+		Duration  string `json:"duration"`
+		Findings  int    `json:"findings"`
+		Baselined int    `json:"baselined,omitempty"`
+		Reason    string `json:"reason,omitempty"`
+		Error     string `json:"error,omitempty"`
+	}
+	report := struct {
+		Steps    []step    `json:"steps"`
+		Findings []Finding `json:"findings"`
+	}{Steps: make([]step, 0, len(results)), Findings: AllFindings(results)}
+
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	for _, result := range results {
+		s := step{Name: result.Step, Status: result.Status, Duration: result.Duration.Round(time.Millisecond).String(), Findings: result.Count, Baselined: result.Baselined, Reason: result.Reason}
+		if result.Err != nil {
+			s.Error = result.Err.Error()
+		}
+		report.Steps = append(report.Steps, s)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func writeCheckstyle(w io.Writer, results []Result) error {
+	type checkstyleError struct {
+		Line     int    `xml:"line,attr"`
+		Column   int    `xml:"column,attr,omitempty"`
+		Severity string `xml:"severity,attr"`
+		Message  string `xml:"message,attr"`
+		Source   string `xml:"source,attr"`
+	}
+	type checkstyleFile struct {
+		Name   string            `xml:"name,attr"`
+		Errors []checkstyleError `xml:"error"`
+	}
+	report := struct {
+		XMLName xml.Name         `xml:"checkstyle"`
+		Version string           `xml:"version,attr"`
+		Files   []checkstyleFile `xml:"file"`
+	}{Version: "5.0"}
+
+	for _, group := range GroupByFile(AllFindings(results)) {
+		file := checkstyleFile{Name: group[0].File}
+		for _, f := range group {
+			file.Errors = append(file.Errors, checkstyleError{Line: f.Line, Column: f.Column, Severity: f.Severity, Message: f.Message, Source: f.Linter})
+		}
+		report.Files = append(report.Files, file)
+	}
+	return writeXML(w, report)
+}
+
+func writeJUnit(w io.Writer, results []Result) error {
+	type failure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+	type testCase struct {
+		Name      string    `xml:"name,attr"`
+		ClassName string    `xml:"classname,attr"`
+		Time      string    `xml:"time,attr"`
+		Failure   *failure  `xml:"failure,omitempty"`
+		Skipped   *struct{} `xml:"skipped,omitempty"`
+	}
+	type testSuite struct {
+		Name     string     `xml:"name,attr"`
+		Tests    int        `xml:"tests,attr"`
+		Failures int        `xml:"failures,attr"`
+		Skipped  int        `xml:"skipped,attr"`
+		Time     string     `xml:"time,attr"`
+		Cases    []testCase `xml:"testcase"`
+	}
+	report := struct {
+		XMLName xml.Name    `xml:"testsuites"`
+		Suites  []testSuite `xml:"testsuite"`
+	}{}
+
+	// one suite per step, with a failed case per finding or a single case for the whole step
+	for _, result := range results {
+		seconds := fmt.Sprintf("%.3f", result.Duration.Seconds())
+		suite := testSuite{Name: result.Step, Time: seconds}
+		for _, f := range result.Findings {
+			text := fmt.Sprintf("%s: %s (%s)", position(f), f.Message, f.Linter)
+			suite.Cases = append(suite.Cases, testCase{
+				Name:      position(f),
+				ClassName: result.Step,
+				Time:      "0",
+				Failure:   &failure{Message: f.Message, Type: f.Severity, Text: text},
+			})
+		}
+		if len(result.Findings) == 0 {
+			c := testCase{Name: result.Step, ClassName: result.Step, Time: seconds}
+			switch {
+			case result.Status == StatusSkipped:
+				c.Skipped = &struct{}{}
+			case result.Status == StatusFailed && result.Err != nil:
+				c.Failure = &failure{Message: result.Err.Error(), Type: SeverityError}
+			case result.Status == StatusFailed:
+				c.Failure = &failure{Message: fmt.Sprintf("%d finding(s)", result.Count), Type: SeverityError}
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+
+		for _, c := range suite.Cases {
+			suite.Tests++
+			if c.Failure != nil {
+				suite.Failures++
+			}
+			if c.Skipped != nil {
+				suite.Skipped++
+			}
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+	return writeXML(w, report)
+}
+
+// writeGitHub writes GitHub Actions workflow commands, which annotate the lines of the pull request
+func writeGitHub(w io.Writer, results []Result) error {
+	for _, f := range AllFindings(results) {
+		properties := []string{"file=" + escapeProperty(f.File)}
+		if f.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", f.Line))
+		}
+		if f.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", f.Column))
+		}
+		properties = append(properties, "title="+escapeProperty(f.Linter))
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubLevel(f.Severity), strings.Join(properties, ","), escapeData(f.Message)); err != nil {
+			return err
+		}
+	}
+
+	// steps failing without findings, like a broken custom command, have no location
+	for _, result := range results {
+		if result.Status != StatusFailed || len(result.Findings) > 0 {
+			continue
+		}
+		message := fmt.Sprintf("%d finding(s)", result.Count)
+		if result.Err != nil {
+			message = result.Err.Error()
+		}
+		if _, err := fmt.Fprintf(w, "::error title=%s::%s\n", escapeProperty(result.Step), escapeData(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubLevel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// position formats the location of the finding as file:line:column, omitting what is unknown
+func position(f Finding) string {
+	pos := f.File
+	if f.Line > 0 {
+		pos += fmt.Sprintf(":%d", f.Line)
+		if f.Column > 0 {
+			pos += fmt.Sprintf(":%d", f.Column)
+		}
+	}
+	return pos
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent(strconst.Empty, "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, strconst.NewLine)
+	return err
+}
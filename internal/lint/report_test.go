@@ -0,0 +1,133 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testResults() []Result {
+	return []Result{
+		{Step: "gofumpt", Status: StatusFailed, Count: 1, Findings: []Finding{
+			{File: "main.go", Linter: "gofumpt", Severity: SeverityError, Message: "file is not formatted"},
+		}},
+		{Step: "golangci-lint", Status: StatusFailed, Duration: 1500 * time.Millisecond, Count: 2, Err: errors.New("exit status 1"), Findings: []Finding{
+			{File: "main.go", Line: 9, Column: 2, Linter: "errcheck", Severity: SeverityError, Message: "Error return value, is not checked"},
+			{File: "cmd/root.go", Line: 3, Linter: "lll", Severity: SeverityWarning, Message: "line is\n130 characters"},
+		}},
+		{Step: "vet", Status: StatusFailed, Err: errors.New("exit status 2")},
+		{Step: "tidy", Status: StatusSkipped},
+	}
+}
+
+func TestAllFindings(t *testing.T) {
+	var got []string
+	for _, group := range GroupByFile(AllFindings(testResults())) {
+		for _, f := range group {
+			got = append(got, position(f))
+		}
+		got = append(got, "|")
+	}
+	want := "cmd/root.go:3 | main.go main.go:9:2 |"
+	if strings.Join(got, " ") != want {
+		t.Errorf("GroupByFile() failed, got = %v, want = %v", strings.Join(got, " "), want)
+	}
+}
+
+func TestWriteReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatJSON, testResults()); err != nil {
+		t.Fatalf("WriteReport() failed, got unexpected error = %v", err)
+	}
+
+	var got struct {
+		Steps    []map[string]any `json:"steps"`
+		Findings []Finding        `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteReport() failed, got invalid JSON = %v", err)
+	}
+	if len(got.Steps) != 4 || len(got.Findings) != 3 || got.Findings[0].File != "cmd/root.go" {
+		t.Errorf("WriteReport() failed, got = %s", buf.String())
+	}
+	if got.Steps[2]["error"] != "exit status 2" || got.Steps[1]["findings"] != 2.0 {
+		t.Errorf("WriteReport() failed, got steps = %v", got.Steps)
+	}
+}
+
+func TestWriteReportCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatCheckstyle, testResults()); err != nil {
+		t.Fatalf("WriteReport() failed, got unexpected error = %v", err)
+	}
+
+	var got struct {
+		Files []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line     int    `xml:"line,attr"`
+				Severity string `xml:"severity,attr"`
+				Source   string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteReport() failed, got invalid XML = %v", err)
+	}
+	if len(got.Files) != 2 || got.Files[1].Name != "main.go" || len(got.Files[1].Errors) != 2 {
+		t.Fatalf("WriteReport() failed, got = %s", buf.String())
+	}
+	if e := got.Files[0].Errors[0]; e.Line != 3 || e.Severity != SeverityWarning || e.Source != "lll" {
+		t.Errorf("WriteReport() failed, got error = %+v", e)
+	}
+}
+
+func TestWriteReportJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatJUnit, testResults()); err != nil {
+		t.Fatalf("WriteReport() failed, got unexpected error = %v", err)
+	}
+
+	var got struct {
+		Suites []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Skipped  int    `xml:"skipped,attr"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteReport() failed, got invalid XML = %v", err)
+	}
+
+	want := [][3]int{{1, 1, 0}, {2, 2, 0}, {1, 1, 0}, {1, 0, 1}}
+	if len(got.Suites) != len(want) {
+		t.Fatalf("WriteReport() failed, got = %s", buf.String())
+	}
+	for i, suite := range got.Suites {
+		if [3]int{suite.Tests, suite.Failures, suite.Skipped} != want[i] {
+			t.Errorf("WriteReport() failed, suite %s got tests, failures, skipped = %d, %d, %d, want = %v", suite.Name, suite.Tests, suite.Failures, suite.Skipped, want[i])
+		}
+	}
+}
+
+func TestWriteReportGitHub(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, FormatGitHub, testResults()); err != nil {
+		t.Fatalf("WriteReport() failed, got unexpected error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"::warning file=cmd/root.go,line=3,title=lll::line is%0A130 characters",
+		"::error file=main.go,title=gofumpt::file is not formatted",
+		"::error file=main.go,line=9,col=2,title=errcheck::Error return value, is not checked",
+		"::error title=vet::exit status 2",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteReport() failed, got = %q, want = %q", buf.String(), want)
+	}
+}
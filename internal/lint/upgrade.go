@@ -0,0 +1,320 @@
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Upgrade tells what UpgradeConfig changed
+type Upgrade struct {
+	From, To Profile
+	// Added and Removed are the enabled linters changed by the upgrade
+	Added, Removed []string
+	// Kept are the local edits which were preserved instead of being upgraded
+	Kept []string
+}
+
+// UpToDate tells whether the configuration was already at the latest revision of the profile
+func (u *Upgrade) UpToDate() bool {
+	return u.From.Name == u.To.Name && u.From.Revision == u.To.Revision
+}
+
+// UpgradeConfig migrates a .golangci.yml written from a revision of a profile to the latest
+// revision of the target profile, the same profile when target is empty. It is a three-way
+// merge of the linters section: what the project did not change since it was written follows
+// the new revision, while its local edits are kept. A configuration without marker is taken
+// as the one written by 'godev init' before the profiles existed
+func UpgradeConfig(data []byte, target string) ([]byte, *Upgrade, error) {
+	name, revision, ok := ParseMarker(data)
+	if !ok {
+		name, revision = ProfileStandard, 1
+	} else {
+		// the marker is rewritten below
+		_, rest, _ := strings.Cut(string(data), strconst.NewLine)
+		data = []byte(rest)
+	}
+	from, ok := findRevision(name, revision)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown lint profile %s revision %d", name, revision)
+	}
+	if target == strconst.Empty {
+		target = name
+	}
+	to, err := FindProfile(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upgrade := &Upgrade{From: from, To: to}
+	if upgrade.UpToDate() {
+		return nil, upgrade, nil
+	}
+
+	doc, err := parseConfig(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := doc.Content[0]
+	section := migrateV1Keys(root)
+
+	if err := upgradeLinters(section, upgrade); err != nil {
+		return nil, nil, err
+	}
+	if err := upgradeSettings(section, upgrade); err != nil {
+		return nil, nil, err
+	}
+	if err := upgradeRules(section, upgrade); err != nil {
+		return nil, nil, err
+	}
+
+	out, err := encodeYAML(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte(to.Marker() + strconst.NewLine + out), upgrade, nil
+}
+
+// migrateV1Keys moves the golangci-lint v1 keys written by older versions of godev to their v2
+// place, returning the linters section
+func migrateV1Keys(root *yaml.Node) *yaml.Node {
+	section := mappingValue(root, "linters")
+	if section == nil {
+		section = &yaml.Node{Kind: yaml.MappingNode}
+		setKey(root, "linters", section)
+	}
+	// disable-all: true becomes default: none at the same place
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		if key := section.Content[i]; key.Value == "disable-all" && mappingValue(section, "default") == nil {
+			value := "standard"
+			if section.Content[i+1].Value == "true" {
+				value = "none"
+			}
+			key.Value, section.Content[i+1] = "default", &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		}
+	}
+	deleteKey(section, "disable-all")
+	if mappingValue(section, "default") == nil {
+		section.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Value: "default"}, {Kind: yaml.ScalarNode, Value: "none"}}, section.Content...)
+	}
+
+	issues := mappingValue(root, "issues")
+	if issues == nil {
+		return section
+	}
+	if rules := mappingValue(issues, "exclude-rules"); rules != nil {
+		excl := mappingValue(section, "exclusions")
+		if excl == nil {
+			excl = &yaml.Node{Kind: yaml.MappingNode}
+			setKey(section, "exclusions", excl)
+		}
+		if existing := mappingValue(excl, "rules"); existing != nil {
+			existing.Content = append(existing.Content, rules.Content...)
+		} else {
+			setKey(excl, "rules", rules)
+		}
+		deleteKey(issues, "exclude-rules")
+	}
+	if len(issues.Content) == 0 {
+		deleteKey(root, "issues")
+	}
+	return section
+}
+
+func upgradeLinters(section *yaml.Node, upgrade *Upgrade) error {
+	node := mappingValue(section, "enable")
+	if node == nil {
+		node = &yaml.Node{Kind: yaml.SequenceNode}
+		setKey(section, "enable", node)
+	}
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("invalid linters.enable: not a list")
+	}
+	from, to := upgrade.From.Linters, upgrade.To.Linters
+
+	// the nodes of the kept linters are reused for their comments
+	var enable []*yaml.Node
+	for _, item := range node.Content {
+		linter := item.Value
+		// dropped by the new revision
+		if slices.Contains(from, linter) && !slices.Contains(to, linter) {
+			upgrade.Removed = append(upgrade.Removed, linter)
+			continue
+		}
+		if !slices.Contains(from, linter) {
+			upgrade.Kept = append(upgrade.Kept, fmt.Sprintf("linter %s enabled locally", linter))
+		}
+		enable = append(enable, item)
+	}
+	enabled := func(linter string) bool {
+		return slices.ContainsFunc(enable, func(item *yaml.Node) bool { return item.Value == linter })
+	}
+	for _, linter := range to {
+		switch {
+		case enabled(linter):
+		case slices.Contains(from, linter):
+			// in both revisions, so the project disabled it
+			upgrade.Kept = append(upgrade.Kept, fmt.Sprintf("linter %s disabled locally", linter))
+		default:
+			upgrade.Added = append(upgrade.Added, linter)
+			enable = append(enable, &yaml.Node{Kind: yaml.ScalarNode, Value: linter})
+		}
+	}
+	node.Content = enable
+	return nil
+}
+
+func upgradeSettings(section *yaml.Node, upgrade *Upgrade) error {
+	settings := mappingValue(section, "settings")
+	if settings == nil {
+		settings = &yaml.Node{Kind: yaml.MappingNode}
+	}
+
+	from, err := normalize(upgrade.From.Settings)
+	if err != nil {
+		return err
+	}
+	to, err := normalize(upgrade.To.Settings)
+	if err != nil {
+		return err
+	}
+	fromSettings, _ := from.(map[string]any)
+	toSettings, _ := to.(map[string]any)
+
+	linters := make([]string, 0, len(fromSettings)+len(toSettings))
+	for linter := range fromSettings {
+		linters = append(linters, linter)
+	}
+	for linter := range toSettings {
+		linters = append(linters, linter)
+	}
+	slices.Sort(linters)
+
+	for _, linter := range slices.Compact(linters) {
+		var local any
+		node := mappingValue(settings, linter)
+		if node != nil {
+			if err := node.Decode(&local); err != nil {
+				return fmt.Errorf("invalid linters.settings.%s: %w", linter, err)
+			}
+		}
+		old, inFrom := fromSettings[linter]
+		latest, inTo := toSettings[linter]
+
+		switch {
+		case node != nil && (!inFrom || !reflect.DeepEqual(local, old)):
+			if !reflect.DeepEqual(local, latest) {
+				upgrade.Kept = append(upgrade.Kept, fmt.Sprintf("settings of %s", linter))
+			}
+		case node == nil && inFrom:
+			upgrade.Kept = append(upgrade.Kept, fmt.Sprintf("settings of %s removed locally", linter))
+		case inTo:
+			var value yaml.Node
+			if err := value.Encode(latest); err != nil {
+				return err
+			}
+			setKey(settings, linter, &value)
+		default:
+			deleteKey(settings, linter)
+		}
+	}
+
+	if len(settings.Content) == 0 {
+		deleteKey(section, "settings")
+	} else if mappingValue(section, "settings") == nil {
+		setKey(section, "settings", settings)
+	}
+	return nil
+}
+
+func upgradeRules(section *yaml.Node, upgrade *Upgrade) error {
+	excl := mappingValue(section, "exclusions")
+	if excl == nil {
+		excl = &yaml.Node{Kind: yaml.MappingNode}
+	}
+	node := mappingValue(excl, "rules")
+	if node == nil {
+		node = &yaml.Node{Kind: yaml.SequenceNode}
+	}
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("invalid linters.exclusions.rules: not a list")
+	}
+
+	from, err := normalize(upgrade.From.Rules)
+	if err != nil {
+		return err
+	}
+	fromRules, _ := from.([]any)
+	contains := func(rules []any, rule any) bool {
+		return slices.ContainsFunc(rules, func(r any) bool { return reflect.DeepEqual(r, rule) })
+	}
+
+	var toRules []any
+	for _, rule := range upgrade.To.Rules {
+		normalized, err := normalize(rule)
+		if err != nil {
+			return err
+		}
+		toRules = append(toRules, normalized)
+	}
+
+	var local []any
+	var kept []*yaml.Node
+	for _, item := range node.Content {
+		var rule any
+		if err := item.Decode(&rule); err != nil {
+			return fmt.Errorf("invalid linters.exclusions.rules: %w", err)
+		}
+		if contains(fromRules, rule) && !contains(toRules, rule) {
+			continue
+		}
+		if !contains(fromRules, rule) {
+			upgrade.Kept = append(upgrade.Kept, fmt.Sprintf("exclusion rule at line %d", item.Line))
+		}
+		kept, local = append(kept, item), append(local, rule)
+	}
+	for i, rule := range toRules {
+		if contains(local, rule) || contains(fromRules, rule) {
+			continue
+		}
+		var item yaml.Node
+		if err := item.Encode(upgrade.To.Rules[i]); err != nil {
+			return err
+		}
+		kept = append(kept, &item)
+	}
+	node.Content = kept
+
+	switch {
+	case len(node.Content) > 0:
+		setKey(excl, "rules", node)
+		if mappingValue(section, "exclusions") == nil {
+			setKey(section, "exclusions", excl)
+		}
+	case mappingValue(section, "exclusions") != nil:
+		deleteKey(excl, "rules")
+		if len(excl.Content) == 0 {
+			deleteKey(section, "exclusions")
+		}
+	}
+	return nil
+}
+
+// normalize converts a value to the generic form decoded from YAML, to compare it with the
+// content of a configuration file
+func normalize(v any) (any, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
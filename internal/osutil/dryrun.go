@@ -0,0 +1,126 @@
+package osutil
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// dry-run state shared by the runner and the file helpers, set once by the root command
+var (
+	dryRunMu sync.Mutex
+	dryRun   bool
+	explain  bool
+	planOut  io.Writer = os.Stdout
+	script   []string
+)
+
+// SetDryRun makes Run and the file helpers print what they would do instead of doing it,
+// collecting an equivalent shell script printed by WriteScript instead when explain is set
+func SetDryRun(enabled, asScript bool) {
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	dryRun, explain, script = enabled || asScript, asScript, nil
+}
+
+// DryRun tells whether commands and file writes are only printed
+func DryRun() bool {
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	return dryRun
+}
+
+// WriteScript writes the shell script collected in explain mode, nothing otherwise
+func WriteScript(w io.Writer) error {
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	if !explain {
+		return nil
+	}
+
+	lines := append([]string{"#!/bin/sh", "set -e"}, script...)
+	_, err := io.WriteString(w, strings.Join(lines, strconst.NewLine)+strconst.NewLine)
+	return err
+}
+
+// plan prints the shell command of a skipped action, or adds it to the script in explain mode
+func plan(shell string) {
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	if explain {
+		script = append(script, shell)
+		return
+	}
+	_, _ = fmt.Fprintf(planOut, "[dry-run] %s\n", shell)
+}
+
+// PlanWrite records a file godev would write with its own code, like an archive, in dry-run mode
+func PlanWrite(path, description string) {
+	plan(fmt.Sprintf("# write %s: %s", shellQuote(path), description))
+}
+
+// ShellLine returns the command as a POSIX shell command line, with its directory and env overrides
+func (c Command) ShellLine() string {
+	words := make([]string, 0, len(c.Env)+len(c.Args)+1)
+	for _, kv := range c.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		words = append(words, key+"="+shellQuote(value))
+	}
+	words = append(words, shellQuote(c.Name))
+	for _, arg := range c.Args {
+		words = append(words, shellQuote(arg))
+	}
+
+	line := strings.Join(words, strconst.Space)
+	if c.Dir != strconst.Empty {
+		return fmt.Sprintf("(cd %s && %s)", shellQuote(c.Dir), line)
+	}
+	return line
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// WriteFile is os.WriteFile, printed instead in dry-run mode
+func WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if DryRun() {
+		content := string(data)
+		if strings.HasSuffix(content, strconst.NewLine) && !strings.Contains(content, "\nGODEV_EOF\n") {
+			plan(fmt.Sprintf("cat > %s <<'GODEV_EOF'\n%sGODEV_EOF", shellQuote(path), content))
+		} else {
+			plan(fmt.Sprintf("printf '%%s' %s > %s", shellQuote(content), shellQuote(path)))
+		}
+		return nil
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// MkdirAll is os.MkdirAll, printed instead in dry-run mode
+func MkdirAll(path string, perm fs.FileMode) error {
+	if DryRun() {
+		plan("mkdir -p " + shellQuote(path))
+		return nil
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// Remove is os.Remove, printed instead in dry-run mode
+func Remove(path string) error {
+	if DryRun() {
+		plan("rm -f " + shellQuote(path))
+		return nil
+	}
+	return os.Remove(path)
+}
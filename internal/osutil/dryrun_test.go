@@ -0,0 +1,99 @@
+package osutil
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShellLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		want    string
+	}{
+		{
+			name:    "plain command",
+			command: Command{Name: "go", Args: []string{"test", "./..."}},
+			want:    "go test ./...",
+		},
+		{
+			name:    "env overrides and quoting",
+			command: Command{Name: "go", Args: []string{"build", "-ldflags", "-X main.version=v1.0.0"}, Env: []string{"GOOS=linux", "MSG=it's"}},
+			want:    `GOOS=linux MSG='it'\''s' go build -ldflags '-X main.version=v1.0.0'`,
+		},
+		{
+			name:    "working directory",
+			command: Command{Name: "gofumpt", Args: []string{"-w", "."}, Dir: "/my project"},
+			want:    "(cd '/my project' && gofumpt -w .)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.command.ShellLine(); got != tt.want {
+				t.Errorf("ShellLine() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var out strings.Builder
+	stdout := planOut
+	planOut = &out
+	SetDryRun(true, false)
+	t.Cleanup(func() {
+		SetDryRun(false, false)
+		planOut = stdout
+	})
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "sub", "file.txt")
+	if err := MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(file, []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if exist, _ := CheckExist(filepath.Dir(file)); exist {
+		t.Errorf("MkdirAll() failed, created %s in dry-run mode", filepath.Dir(file))
+	}
+
+	result, err := Run(context.Background(), helperCommand("exit", "3"))
+	if err != nil || result.ExitCode != 0 {
+		t.Errorf("Run() failed, got = %v, %v, want the command skipped in dry-run mode", result, err)
+	}
+	// read-only queries still run
+	if output, err := CommandOutputContext(context.Background(), helperCommand("echo", "query")); err != nil || output != "query" {
+		t.Errorf("CommandOutputContext() failed, got = %v, %v, want = query", output, err)
+	}
+
+	for _, want := range []string{"[dry-run] mkdir -p ", "[dry-run] cat > ", "exit 3"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("dry-run output failed, got = %q, want containing %q", out.String(), want)
+		}
+	}
+}
+
+func TestExplainScript(t *testing.T) {
+	SetDryRun(false, true)
+	t.Cleanup(func() { SetDryRun(false, false) })
+
+	if _, err := Run(context.Background(), Command{Name: "go", Args: []string{"mod", "tidy"}, Dir: "/src"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveDirIfExist(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	var script strings.Builder
+	if err := WriteScript(&script); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(script.String()), "\n")
+	if len(lines) != 4 || lines[0] != "#!/bin/sh" || lines[2] != "(cd /src && go mod tidy)" || !strings.HasPrefix(lines[3], "rm -rf ") {
+		t.Errorf("WriteScript() failed, got = %q", lines)
+	}
+}
@@ -1,27 +1,69 @@
 package osutil
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/thought2code/godev/internal/strconst"
 )
 
 func RunCommand(cmd string, args ...string) error {
-	command := exec.Command(cmd, args...)
-	output, err := command.CombinedOutput()
+	return RunCommandWithEnv(nil, cmd, args...)
+}
 
-	fmt.Printf("%s %s %s\n", strconst.EmojiRunning, cmd, strings.Join(args, strconst.Space))
+// RunCommandWithEnv runs the command like RunCommand, with the given KEY=VALUE pairs
+// appended to the environment of the current process
+func RunCommandWithEnv(env []string, cmd string, args ...string) error {
+	return RunCommandContext(context.Background(), Command{Name: cmd, Args: args, Env: env})
+}
 
-	if len(output) > 0 {
-		fmt.Print(string(output))
+// RunCommandContext prints the command line, streams the output of the command to the standard
+// output and error of godev unless c redirects them, then prints the success with the duration
+func RunCommandContext(ctx context.Context, c Command) error {
+	if !c.ReadOnly && DryRun() {
+		_, err := Run(ctx, c)
+		return err
 	}
+	fmt.Printf("%s %s\n", strconst.EmojiRunning, c)
 
+	if c.Stdout == nil {
+		c.Stdout = os.Stdout
+	}
+	if c.Stderr == nil {
+		c.Stderr = os.Stderr
+	}
+	result, err := Run(ctx, c)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s %s %s\n", strconst.EmojiSuccess, cmd, strings.Join(args, strconst.Space))
+	fmt.Printf("%s %s (%s)\n", strconst.EmojiSuccess, c, result.Duration.Round(time.Millisecond))
 	return nil
 }
+
+// CommandOutput runs the command quietly and returns its trimmed standard output
+func CommandOutput(cmd string, args ...string) (string, error) {
+	return CommandOutputWithEnv(nil, cmd, args...)
+}
+
+// CommandOutputWithEnv is CommandOutput with the KEY=VALUE pairs appended to the environment
+func CommandOutputWithEnv(env []string, cmd string, args ...string) (string, error) {
+	return CommandOutputContext(context.Background(), Command{Name: cmd, Args: args, Env: env})
+}
+
+// CommandOutputContext runs the command quietly and returns its trimmed standard output,
+// the error includes the standard error of the command. Such queries run even in dry-run mode
+func CommandOutputContext(ctx context.Context, c Command) (string, error) {
+	c.Capture, c.ReadOnly = true, true
+	result, err := Run(ctx, c)
+	if err != nil {
+		if msg := strings.TrimSpace(result.Stderr); msg != strconst.Empty {
+			return strconst.Empty, fmt.Errorf("%s: %w: %s", c, err, msg)
+		}
+		return strconst.Empty, fmt.Errorf("%s: %w", c, err)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
@@ -35,3 +35,38 @@ func TestRunCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestRunCommandWithEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     []string
+		cmd     string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "run command with env success",
+			env:     []string{"GOFLAGS=-mod=mod"},
+			cmd:     "go",
+			args:    []string{"env", "GOFLAGS"},
+			wantErr: false,
+		},
+		{
+			name:    "run command with invalid env value failed",
+			env:     []string{"GOTOOLCHAIN=not-a-toolchain"},
+			cmd:     "go",
+			args:    []string{"version"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := RunCommandWithEnv(tt.env, tt.cmd, tt.args...)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("RunCommandWithEnv() failed, got unexpected error: %v", gotErr)
+				return
+			}
+		})
+	}
+}
@@ -37,6 +37,10 @@ func RemoveDirIfExist(dir string) error {
 		return nil
 	}
 
+	if DryRun() {
+		plan("rm -rf " + shellQuote(dir))
+		return nil
+	}
 	if err := os.RemoveAll(dir); err != nil {
 		return err
 	}
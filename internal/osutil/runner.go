@@ -0,0 +1,170 @@
+package osutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// cancelGracePeriod is how long a cancelled command may take to exit after the interrupt
+// signal before it is killed
+const cancelGracePeriod = 5 * time.Second
+
+// Command describes a process started by Run
+type Command struct {
+	Name string
+	Args []string
+
+	// Dir is the working directory, the current directory when empty
+	Dir string
+	// Env holds KEY=VALUE pairs appended to the environment of the current process
+	Env []string
+	// Timeout cancels the command after the duration, no timeout when zero
+	Timeout time.Duration
+
+	Stdin io.Reader
+	// Stdout and Stderr receive the output while the command runs, nil discards it
+	Stdout io.Writer
+	Stderr io.Writer
+	// Capture keeps the output in the Result, in addition to streaming it
+	Capture bool
+	// ReadOnly commands, like queries of go env or git, still run in dry-run mode
+	ReadOnly bool
+}
+
+// String returns the command line, e.g. go test ./...
+func (c Command) String() string {
+	return strings.TrimSpace(c.Name + strconst.Space + strings.Join(c.Args, strconst.Space))
+}
+
+// Result is the outcome of a command run by Run
+type Result struct {
+	// ExitCode is -1 when the command did not start or was killed by a signal
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Duration time.Duration
+}
+
+// Run runs the command until it exits or ctx is done. Cancelling ctx interrupts the command,
+// then kills it if it does not exit within a grace period. The returned error is an
+// *exec.ExitError for non-zero exit codes, and wraps ctx.Err() when the command was cancelled.
+// In dry-run mode only ReadOnly commands run, the others are printed and succeed
+func Run(ctx context.Context, c Command) (*Result, error) {
+	if !c.ReadOnly && DryRun() {
+		plan(c.ShellLine())
+		return &Result{}, nil
+	}
+
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	command := exec.CommandContext(ctx, c.Name, c.Args...)
+	command.Dir = c.Dir
+	if len(c.Env) > 0 {
+		command.Env = append(os.Environ(), c.Env...)
+	}
+	command.Stdin = c.Stdin
+	if runtime.GOOS != "windows" {
+		// let tools like go test clean up, Windows has no interrupt signal for other processes
+		command.Cancel = func() error { return command.Process.Signal(os.Interrupt) }
+	}
+	command.WaitDelay = cancelGracePeriod
+
+	var stdout, stderr bytes.Buffer
+	command.Stdout, command.Stderr = c.Stdout, c.Stderr
+	if c.Capture {
+		command.Stdout, command.Stderr = teeWriter(c.Stdout, &stdout), teeWriter(c.Stderr, &stderr)
+		if c.Stdout != nil && c.Stdout == c.Stderr {
+			// keep a shared destination like a terminal in the order the command wrote to it
+			shared := &syncWriter{w: c.Stdout}
+			command.Stdout, command.Stderr = io.MultiWriter(shared, &stdout), io.MultiWriter(shared, &stderr)
+		}
+	}
+
+	start := time.Now()
+	err := command.Run()
+	result := &Result{
+		ExitCode: -1,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: time.Since(start),
+	}
+	if command.ProcessState != nil {
+		result.ExitCode = command.ProcessState.ExitCode()
+	}
+
+	if err != nil && ctx.Err() != nil {
+		return result, errors.Join(ctx.Err(), err)
+	}
+	return result, err
+}
+
+func teeWriter(stream io.Writer, capture *bytes.Buffer) io.Writer {
+	if stream == nil {
+		return capture
+	}
+	return io.MultiWriter(stream, capture)
+}
+
+// syncWriter serializes the writes of the stdout and stderr copying goroutines of exec
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// LineWriter calls a function with each complete line written to it, Flush passes the
+// last line when the output does not end with a newline
+type LineWriter struct {
+	mu     sync.Mutex
+	onLine func(line string)
+	buf    []byte
+}
+
+func NewLineWriter(onLine func(line string)) *LineWriter {
+	return &LineWriter{onLine: onLine}
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.onLine(strings.TrimSuffix(string(w.buf[:i]), "\r"))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.onLine(string(w.buf))
+		w.buf = nil
+	}
+}
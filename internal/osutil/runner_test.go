@@ -0,0 +1,191 @@
+package osutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHelperProcess is the fake executable started by helperCommand, not a real test
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GODEV_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+
+	switch args[0] {
+	case "echo":
+		fmt.Println(strings.Join(args[1:], " "))
+	case "stderr":
+		fmt.Fprintln(os.Stderr, strings.Join(args[1:], " "))
+	case "cat":
+		_, _ = io.Copy(os.Stdout, os.Stdin)
+	case "env":
+		fmt.Println(os.Getenv(args[1]))
+	case "pwd":
+		dir, _ := os.Getwd()
+		fmt.Println(dir)
+	case "exit":
+		code, _ := strconv.Atoi(args[1])
+		fmt.Println("exiting")
+		os.Exit(code)
+	case "sleep":
+		time.Sleep(time.Minute)
+	}
+	os.Exit(0)
+}
+
+func helperCommand(args ...string) Command {
+	return Command{
+		Name: os.Args[0],
+		Args: append([]string{"-test.run=^TestHelperProcess$", "--"}, args...),
+		Env:  []string{"GODEV_WANT_HELPER_PROCESS=1"},
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name         string
+		command      Command
+		wantStdout   string
+		wantStderr   string
+		wantExitCode int
+		wantErr      bool
+	}{
+		{
+			name:       "capture stdout",
+			command:    helperCommand("echo", "hello", "world"),
+			wantStdout: "hello world\n",
+		},
+		{
+			name:       "capture stderr separately",
+			command:    helperCommand("stderr", "oops"),
+			wantStderr: "oops\n",
+		},
+		{
+			name: "stdin",
+			command: func() Command {
+				c := helperCommand("cat")
+				c.Stdin = strings.NewReader("from stdin\n")
+				return c
+			}(),
+			wantStdout: "from stdin\n",
+		},
+		{
+			name: "env appended to the environment",
+			command: func() Command {
+				c := helperCommand("env", "GODEV_TEST_VALUE")
+				c.Env = append(c.Env, "GODEV_TEST_VALUE=42")
+				return c
+			}(),
+			wantStdout: "42\n",
+		},
+		{
+			name: "working directory",
+			command: func() Command {
+				c := helperCommand("pwd")
+				c.Dir = dir
+				return c
+			}(),
+			wantStdout: dir + "\n",
+		},
+		{
+			name:         "non-zero exit code",
+			command:      helperCommand("exit", "3"),
+			wantStdout:   "exiting\n",
+			wantExitCode: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "command not found",
+			command:      Command{Name: filepath.Join(dir, "not-a-command")},
+			wantExitCode: -1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.command.Capture = true
+			got, err := Run(context.Background(), tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() failed, got unexpected error: %v", err)
+			}
+			if got.ExitCode != tt.wantExitCode {
+				t.Errorf("Run() failed, got exit code = %v, want = %v", got.ExitCode, tt.wantExitCode)
+			}
+			if got.Stdout != tt.wantStdout || got.Stderr != tt.wantStderr {
+				t.Errorf("Run() failed, got stdout = %q, stderr = %q, want = %q, %q", got.Stdout, got.Stderr, tt.wantStdout, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunStreams(t *testing.T) {
+	var stdout, stderr strings.Builder
+	c := helperCommand("echo", "streamed")
+	c.Stdout, c.Stderr = &stdout, &stderr
+
+	got, err := Run(context.Background(), c)
+	if err != nil {
+		t.Fatalf("Run() failed, got unexpected error: %v", err)
+	}
+	if stdout.String() != "streamed\n" || stderr.String() != "" {
+		t.Errorf("Run() failed, got streamed stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if got.Stdout != "" {
+		t.Errorf("Run() failed, got captured stdout = %q without Capture", got.Stdout)
+	}
+	if got.Duration <= 0 {
+		t.Errorf("Run() failed, got duration = %v", got.Duration)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	c := helperCommand("sleep")
+	c.Timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	got, err := Run(context.Background(), c)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() failed, got error = %v, want = %v", err, context.DeadlineExceeded)
+	}
+	if got.ExitCode == 0 {
+		t.Errorf("Run() failed, got exit code 0 for a cancelled command")
+	}
+	if elapsed := time.Since(start); elapsed > cancelGracePeriod {
+		t.Errorf("Run() failed, the command ran for %v after the timeout", elapsed)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := NewLineWriter(func(line string) { lines = append(lines, line) })
+
+	for _, chunk := range []string{"go: down", "loading a\r\ngo: downloading b\n", "partial"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	want := []string{"go: downloading a", "go: downloading b", "partial"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("LineWriter failed, got lines = %q, want = %q", lines, want)
+	}
+}
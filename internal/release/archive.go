@@ -0,0 +1,134 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+// ArchiveFormat picks zip for windows and tar.gz for everything else unless a format is forced
+func ArchiveFormat(goos, forced string) string {
+	if forced != "" && forced != "auto" {
+		return forced
+	}
+	if goos == "windows" {
+		return FormatZip
+	}
+	return FormatTarGz
+}
+
+// CreateArchive writes the files flat into a tar.gz or zip archive at dest
+func CreateArchive(dest, format string, files []string) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatTarGz:
+		err = writeTarGz(out, files)
+	case FormatZip:
+		err = writeZip(out, files)
+	default:
+		err = fmt.Errorf("unsupported archive format %q, expected %s or %s", format, FormatTarGz, FormatZip)
+	}
+
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(dest)
+	}
+	return err
+}
+
+func writeTarGz(w io.Writer, files []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+		if err := addTarFile(tw, file); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func addTarFile(tw *tar.Writer, file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.Base(file)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	return copyFile(tw, file)
+}
+
+func writeZip(w io.Writer, files []string) error {
+	zw := zip.NewWriter(w)
+
+	for _, file := range files {
+		if err := addZipFile(zw, file); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func addZipFile(zw *zip.Writer, file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.Base(file)
+	header.Method = zip.Deflate
+
+	fw, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	return copyFile(fw, file)
+}
+
+func copyFile(w io.Writer, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// ArchiveName returns the conventional archive name, e.g. app_v1.2.0_linux_amd64.tar.gz
+func ArchiveName(project, version, goos, goarch, format string) string {
+	return strings.Join([]string{project, version, goos, goarch}, "_") + "." + format
+}
@@ -0,0 +1,130 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestArchiveFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		goos   string
+		forced string
+		want   string
+	}{
+		{name: "linux defaults to tar.gz", goos: "linux", forced: "auto", want: FormatTarGz},
+		{name: "windows defaults to zip", goos: "windows", forced: "auto", want: FormatZip},
+		{name: "forced format wins", goos: "windows", forced: FormatTarGz, want: FormatTarGz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArchiveFormat(tt.goos, tt.forced); got != tt.want {
+				t.Errorf("ArchiveFormat() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateArchive(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+
+	binDir := filepath.Join(tempDir, "linux_amd64")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	files := []string{filepath.Join(binDir, "app"), filepath.Join(tempDir, "LICENSE")}
+	for _, file := range files {
+		if err := os.WriteFile(file, []byte("test"), 0o644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	want := []string{"app", "LICENSE"}
+
+	tests := []struct {
+		name    string
+		format  string
+		list    func(path string) ([]string, error)
+		wantErr bool
+	}{
+		{name: "tar.gz archive", format: FormatTarGz, list: listTarGz, wantErr: false},
+		{name: "zip archive", format: FormatZip, list: listZip, wantErr: false},
+		{name: "unsupported format", format: "rar", list: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := filepath.Join(tempDir, "app."+tt.format)
+			gotErr := CreateArchive(dest, tt.format, files)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("CreateArchive() failed, got unexpected error = %v", gotErr)
+				return
+			}
+			if tt.wantErr {
+				if _, err := os.Stat(dest); err == nil {
+					t.Errorf("CreateArchive() failed, broken archive was not removed: %s", dest)
+				}
+				return
+			}
+
+			got, err := tt.list(dest)
+			if err != nil {
+				t.Fatalf("Failed to read archive: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("CreateArchive() failed, got = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+func listTarGz(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	return names, nil
+}
+
+func listZip(path string) ([]string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	return names, nil
+}
+
+func TestArchiveName(t *testing.T) {
+	want := "app_v1.0.0_darwin_arm64.tar.gz"
+	if got := ArchiveName("app", "v1.0.0", "darwin", "arm64", FormatTarGz); got != want {
+		t.Errorf("ArchiveName() failed, got = %v, want = %v", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/thought2code/godev/internal/gitutil"
+)
+
+// ChangelogRange returns the git revision range covering the changes of version, i.e. from the
+// tag before it up to the version tag, or from the latest tag up to HEAD when version is not
+// tagged yet. The tags are sorted from the newest
+func ChangelogRange(version string, tags []string) string {
+	i := slices.Index(tags, version)
+	switch {
+	case len(tags) == 0:
+		return "HEAD"
+	case i < 0:
+		return tags[0] + "..HEAD"
+	case i == len(tags)-1:
+		// the oldest tag covers the whole history
+		return version
+	default:
+		return tags[i+1] + ".." + version
+	}
+}
+
+var conventionalCommit = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+var changelogSections = []struct {
+	title string
+	types []string
+}{
+	{title: "Breaking Changes"},
+	{title: "Features", types: []string{"feat"}},
+	{title: "Bug Fixes", types: []string{"fix"}},
+	{title: "Performance", types: []string{"perf"}},
+	{title: "Documentation", types: []string{"docs"}},
+	{title: "Other Changes"},
+}
+
+// RenderChangelog renders the commits as a markdown changelog grouped by conventional commit type
+func RenderChangelog(version, date string, commits []gitutil.Commit) string {
+	entries := make(map[string][]string, len(changelogSections))
+	for _, c := range commits {
+		title, entry := classifyCommit(c)
+		entries[title] = append(entries[title], entry)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%s)\n", version, date)
+	if len(commits) == 0 {
+		sb.WriteString("\nNo changes.\n")
+	}
+	for _, section := range changelogSections {
+		if len(entries[section.title]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.title)
+		for _, entry := range entries[section.title] {
+			fmt.Fprintf(&sb, "- %s\n", entry)
+		}
+	}
+	return sb.String()
+}
+
+func classifyCommit(c gitutil.Commit) (title, entry string) {
+	other := changelogSections[len(changelogSections)-1].title
+
+	match := conventionalCommit.FindStringSubmatch(c.Subject)
+	if match == nil {
+		return other, fmt.Sprintf("%s (%s)", c.Subject, c.Hash)
+	}
+
+	commitType, scope, breaking, description := strings.ToLower(match[1]), match[2], match[3], match[4]
+	if scope != "" {
+		description = fmt.Sprintf("**%s:** %s", scope, description)
+	}
+	entry = fmt.Sprintf("%s (%s)", description, c.Hash)
+
+	if breaking != "" {
+		return changelogSections[0].title, entry
+	}
+	for _, section := range changelogSections {
+		for _, t := range section.types {
+			if t == commitType {
+				return section.title, entry
+			}
+		}
+	}
+	return other, entry
+}
@@ -0,0 +1,62 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/thought2code/godev/internal/gitutil"
+)
+
+func TestChangelogRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		tags    []string
+		want    string
+	}{
+		{name: "no tags", version: "abc1234", tags: nil, want: "HEAD"},
+		{name: "untagged version", version: "v1.1.0-2-gabc1234", tags: []string{"v1.1.0", "v1.0.0"}, want: "v1.1.0..HEAD"},
+		{name: "tagged version", version: "v1.1.0", tags: []string{"v1.1.0", "v1.0.0"}, want: "v1.0.0..v1.1.0"},
+		{name: "first tag", version: "v1.0.0", tags: []string{"v1.0.0"}, want: "v1.0.0"},
+		{name: "older tagged version", version: "v1.1.0", tags: []string{"v1.2.0", "v1.1.0", "v1.0.0"}, want: "v1.0.0..v1.1.0"},
+		{name: "oldest tag among others", version: "v1.0.0", tags: []string{"v1.2.0", "v1.1.0", "v1.0.0"}, want: "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChangelogRange(tt.version, tt.tags); got != tt.want {
+				t.Errorf("ChangelogRange() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderChangelog(t *testing.T) {
+	commits := []gitutil.Commit{
+		{Hash: "a1", Subject: "feat(cli): add release command"},
+		{Hash: "a2", Subject: "fix: handle empty tags"},
+		{Hash: "a3", Subject: "feat!: drop go1.20 support"},
+		{Hash: "a4", Subject: "update dependencies"},
+	}
+
+	want := `## v1.0.0 (2025-01-01)
+
+### Breaking Changes
+
+- drop go1.20 support (a3)
+
+### Features
+
+- **cli:** add release command (a1)
+
+### Bug Fixes
+
+- handle empty tags (a2)
+
+### Other Changes
+
+- update dependencies (a4)
+`
+	if got := RenderChangelog("v1.0.0", "2025-01-01", commits); got != want {
+		t.Errorf("RenderChangelog() failed, got = %v, want = %v", got, want)
+	}
+}
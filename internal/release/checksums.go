@@ -0,0 +1,43 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WriteChecksums writes a sha256sum compatible checksums file for the given files
+func WriteChecksums(dest string, files []string) error {
+	sorted := append([]string(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return filepath.Base(sorted[i]) < filepath.Base(sorted[j]) })
+
+	lines := make([]string, 0, len(sorted))
+	for _, file := range sorted {
+		sum, err := SHA256File(file)
+		if err != nil {
+			return fmt.Errorf("failed to checksum %s: %w", file, err)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s", sum, filepath.Base(file)))
+	}
+
+	return os.WriteFile(dest, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+func SHA256File(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,35 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteChecksums(t *testing.T) {
+	// temp dir for test
+	tempDir := t.TempDir()
+
+	files := []string{filepath.Join(tempDir, "b.zip"), filepath.Join(tempDir, "a.tar.gz")}
+	for _, file := range files {
+		if err := os.WriteFile(file, []byte("test"), 0o644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	dest := filepath.Join(tempDir, "checksums.txt")
+	if err := WriteChecksums(dest, files); err != nil {
+		t.Fatalf("WriteChecksums() failed, got unexpected error = %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("Failed to read checksums file: %v", err)
+	}
+	// sha256 of "test"
+	sum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	want := sum + "  a.tar.gz\n" + sum + "  b.zip\n"
+	if string(got) != want {
+		t.Errorf("WriteChecksums() failed, got = %q, want = %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package release
+
+import (
+	"debug/buildinfo"
+	"encoding/json"
+	"os"
+	"runtime/debug"
+)
+
+// Manifest is a minimal SBOM-style description of a built binary and its module dependencies
+type Manifest struct {
+	Binary       string       `json:"binary"`
+	Path         string       `json:"path"`
+	Module       Dependency   `json:"module"`
+	GoVersion    string       `json:"goVersion"`
+	Settings     []Setting    `json:"settings,omitempty"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+type Dependency struct {
+	Path    string      `json:"path"`
+	Version string      `json:"version"`
+	Sum     string      `json:"sum,omitempty"`
+	Replace *Dependency `json:"replace,omitempty"`
+}
+
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ReadManifest reads the build information embedded by the Go toolchain in the binary
+func ReadManifest(binary string) (*Manifest, error) {
+	info, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Binary:       binary,
+		Path:         info.Path,
+		Module:       dependency(&info.Main),
+		GoVersion:    info.GoVersion,
+		Dependencies: make([]Dependency, 0, len(info.Deps)),
+	}
+	for _, s := range info.Settings {
+		manifest.Settings = append(manifest.Settings, Setting{Key: s.Key, Value: s.Value})
+	}
+	for _, dep := range info.Deps {
+		manifest.Dependencies = append(manifest.Dependencies, dependency(dep))
+	}
+	return manifest, nil
+}
+
+func dependency(m *debug.Module) Dependency {
+	dep := Dependency{Path: m.Path, Version: m.Version, Sum: m.Sum}
+	if m.Replace != nil {
+		replace := dependency(m.Replace)
+		dep.Replace = &replace
+	}
+	return dep
+}
+
+func WriteManifests(dest string, manifests []*Manifest) error {
+	data, err := json.MarshalIndent(manifests, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, append(data, '\n'), 0o644)
+}
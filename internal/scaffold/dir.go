@@ -0,0 +1,65 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// TemplateExt is the extension of the template files, removed from the rendered files
+const TemplateExt = ".tpl"
+
+// File is a rendered file of a project, Path being slash separated and relative to the project
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Dest returns the path of the file in the project directory root, an error when the file
+// would be written outside of it, like ../escape.txt
+func (f File) Dest(root string) (string, error) {
+	dest := filepath.Join(root, filepath.FromSlash(f.Path))
+	rel, err := filepath.Rel(root, dest)
+	if err != nil || !filepath.IsLocal(rel) {
+		return strconst.Empty, fmt.Errorf("%s is outside of the project directory %s", f.Path, root)
+	}
+	return dest, nil
+}
+
+// RenderDirs renders the files of the template directories of fsys, a file of a later directory
+// replacing the file at the same path of an earlier directory. The files are sorted by path
+func RenderDirs(fsys fs.FS, dirs []string, data any) ([]File, error) {
+	files := map[string]File{}
+	for _, dir := range dirs {
+		err := fs.WalkDir(fsys, dir, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			content, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			rendered, err := Render(name, content, data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			rel := strings.TrimSuffix(strings.TrimPrefix(name, dir+"/"), TemplateExt)
+			files[rel] = File{Path: rel, Content: rendered}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]File, 0, len(files))
+	for _, file := range files {
+		sorted = append(sorted, file)
+	}
+	slices.SortFunc(sorted, func(a, b File) int { return strings.Compare(a.Path, b.Path) })
+	return sorted, nil
+}
@@ -0,0 +1,69 @@
+package scaffold
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRenderDirs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"template/base/go.mod.tpl":            {Data: []byte("module {{.ModulePath}}\n")},
+		"template/base/.gitignore.tpl":        {Data: []byte("dist/\n")},
+		"template/types/cli/main.go.tpl":      {Data: []byte("package main\n")},
+		"template/types/cli/.gitignore.tpl":   {Data: []byte("dist/\n{{.ProjectName}}\n")},
+		"template/types/cli/internal/a.tpl":   {Data: []byte("{{.ProjectName | upper}}")},
+		"template/features/docker/Dockerfile": {Data: []byte("FROM golang:{{.GoVersion}}\n")},
+	}
+	data := Data{ProjectName: "app", ModulePath: "example.com/app", GoVersion: "1.25.5"}
+
+	files, err := RenderDirs(fsys, []string{"template/base", "template/types/cli", "template/features/docker"}, data)
+	if err != nil {
+		t.Fatalf("RenderDirs() failed, err = %v", err)
+	}
+
+	want := []File{
+		{Path: ".gitignore", Content: []byte("dist/\napp\n")},
+		{Path: "Dockerfile", Content: []byte("FROM golang:1.25.5\n")},
+		{Path: "go.mod", Content: []byte("module example.com/app\n")},
+		{Path: "internal/a", Content: []byte("APP")},
+		{Path: "main.go", Content: []byte("package main\n")},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("RenderDirs() failed, got %d files, want %d", len(files), len(want))
+	}
+	for i, file := range files {
+		if file.Path != want[i].Path || string(file.Content) != string(want[i].Content) {
+			t.Errorf("RenderDirs() file %d = %s %q, want %s %q", i, file.Path, file.Content, want[i].Path, want[i].Content)
+		}
+	}
+
+	if _, err := RenderDirs(fsys, []string{"template/types/missing"}, data); err == nil {
+		t.Errorf("RenderDirs() of a missing directory succeeded, want an error")
+	}
+}
+
+func TestFileDest(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "app")
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "cmd/app/main.go", want: filepath.Join(root, "cmd", "app", "main.go")},
+		{path: "a/../go.mod", want: filepath.Join(root, "go.mod")},
+		{path: "../escape.txt", wantErr: true},
+		{path: "a/../../escape.txt", wantErr: true},
+		{path: "..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := File{Path: tt.path}.Dest(root)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("Dest() failed, got = %v, err = %v, want = %v", got, err, tt.want)
+			}
+		})
+	}
+}
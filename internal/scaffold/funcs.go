@@ -0,0 +1,125 @@
+package scaffold
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Funcs returns the functions available to the project templates
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"title":     Title,
+		"snake":     Snake,
+		"kebab":     Kebab,
+		"camel":     Camel,
+		"pascal":    Pascal,
+		"pkgname":   PackageName,
+		"trim":      strings.TrimSpace,
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"join":      func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"contains":  func(elem string, elems []string) bool { return slices.Contains(elems, elem) },
+		"base":      path.Base,
+		"default": func(fallback, value any) any {
+			if value == nil || value == strconst.Empty {
+				return fallback
+			}
+			return value
+		},
+	}
+}
+
+// words splits an identifier into its words at the separators and the case changes, keeping
+// the acronyms together: "HTTPServer-name" gives HTTP, Server and name
+func words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// "fooBar" and "HTTPServer", the S starting a word
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Snake converts an identifier to snake_case
+func Snake(s string) string {
+	return strings.ToLower(strings.Join(words(s), "_"))
+}
+
+// Kebab converts an identifier to kebab-case
+func Kebab(s string) string {
+	return strings.ToLower(strings.Join(words(s), "-"))
+}
+
+// Pascal converts an identifier to PascalCase
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		b.WriteString(Title(strings.ToLower(word)))
+	}
+	return b.String()
+}
+
+// Camel converts an identifier to camelCase
+func Camel(s string) string {
+	ws := words(s)
+	if len(ws) == 0 {
+		return strconst.Empty
+	}
+	return strings.ToLower(ws[0]) + Pascal(strings.Join(ws[1:], " "))
+}
+
+// Title upper cases the first letter of the string
+func Title(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// PackageName converts a name to a Go package name, lower case letters and digits only:
+// "go-kit_v2" gives gokitv2
+func PackageName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == strconst.Empty || unicode.IsDigit(rune(name[0])) {
+		name = "pkg" + name
+	}
+	return name
+}
@@ -0,0 +1,36 @@
+package scaffold
+
+import "testing"
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantSnake  string
+		wantKebab  string
+		wantCamel  string
+		wantPascal string
+	}{
+		{in: "my-app", wantSnake: "my_app", wantKebab: "my-app", wantCamel: "myApp", wantPascal: "MyApp"},
+		{in: "my_app v2", wantSnake: "my_app_v2", wantKebab: "my-app-v2", wantCamel: "myAppV2", wantPascal: "MyAppV2"},
+		{in: "HTTPServer", wantSnake: "http_server", wantKebab: "http-server", wantCamel: "httpServer", wantPascal: "HttpServer"},
+		{in: "fooBar2Baz", wantSnake: "foo_bar2_baz", wantKebab: "foo-bar2-baz", wantCamel: "fooBar2Baz", wantPascal: "FooBar2Baz"},
+		{in: "", wantSnake: "", wantKebab: "", wantCamel: "", wantPascal: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Snake(tt.in); got != tt.wantSnake {
+				t.Errorf("Snake() failed, got = %v, want = %v", got, tt.wantSnake)
+			}
+			if got := Kebab(tt.in); got != tt.wantKebab {
+				t.Errorf("Kebab() failed, got = %v, want = %v", got, tt.wantKebab)
+			}
+			if got := Camel(tt.in); got != tt.wantCamel {
+				t.Errorf("Camel() failed, got = %v, want = %v", got, tt.wantCamel)
+			}
+			if got := Pascal(tt.in); got != tt.wantPascal {
+				t.Errorf("Pascal() failed, got = %v, want = %v", got, tt.wantPascal)
+			}
+		})
+	}
+}
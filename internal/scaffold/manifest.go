@@ -0,0 +1,207 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// ManifestFile is the manifest at the root of an external template
+const ManifestFile = "godev-template.yaml"
+
+// Manifest describes an external template, every other file of its directory being a file of
+// the project
+type Manifest struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description,omitempty"`
+	Variables   []Variable `yaml:"variables,omitempty"`
+	Files       []FileRule `yaml:"files,omitempty"`
+	Hooks       Hooks      `yaml:"hooks,omitempty"`
+}
+
+// FileRule changes how the files matching Path are created, all the matching rules applying
+type FileRule struct {
+	// Path is a slash separated glob of the template paths, ** matching any directories
+	Path string `yaml:"path"`
+	// Raw files are copied as they are, without rendering, like images or Helm charts
+	Raw bool `yaml:"raw,omitempty"`
+	// Exclude leaves the files out of the project, like the documentation of the template
+	Exclude bool `yaml:"exclude,omitempty"`
+	// When is a condition like 'eq .Vars.database "postgres"', the files are created only when
+	// it is true
+	When string `yaml:"when,omitempty"`
+}
+
+// Hooks are commands run in the project directory, their arguments being rendered
+type Hooks struct {
+	PostInit [][]string `yaml:"post_init,omitempty"`
+}
+
+// LoadManifest reads and validates the manifest of the template
+func LoadManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s not found, the template has no manifest", ManifestFile)
+		}
+		return nil, err
+	}
+
+	var manifest Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+func (m *Manifest) validate() error {
+	if err := validateVariables(m.Variables); err != nil {
+		return err
+	}
+	for i, rule := range m.Files {
+		if rule.Path == strconst.Empty {
+			return fmt.Errorf("files[%d]: empty path", i)
+		}
+		if err := validGlob(rule.Path); err != nil {
+			return fmt.Errorf("files[%d]: invalid path %q: %w", i, rule.Path, err)
+		}
+	}
+	for i, command := range m.Hooks.PostInit {
+		if len(command) == 0 || command[0] == strconst.Empty {
+			return fmt.Errorf("hooks.post_init[%d]: empty command", i)
+		}
+	}
+	return nil
+}
+
+// RenderTemplate renders the files of an external template, following the file rules of its
+// manifest. The paths are rendered too, e.g. cmd/{{.ProjectName}}/main.go
+func RenderTemplate(fsys fs.FS, manifest *Manifest, data Data) ([]File, error) {
+	var files []File
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if name == ManifestFile {
+			return nil
+		}
+
+		raw := false
+		for _, rule := range manifest.Files {
+			if ok, _ := matchGlob(rule.Path, name); !ok {
+				continue
+			}
+			include, err := Eval(ManifestFile, rule.When, data)
+			if err != nil {
+				return fmt.Errorf("files rule %s: %w", rule.Path, err)
+			}
+			if rule.Exclude || !include {
+				return nil
+			}
+			raw = raw || rule.Raw
+		}
+
+		dest, err := RenderString(name, strings.TrimSuffix(name, TemplateExt), data)
+		if err != nil {
+			return err
+		}
+		// a path like {{if .Vars.docker}}Dockerfile{{end}} leaves the file out
+		if dest == strconst.Empty || strings.HasSuffix(dest, "/") {
+			return nil
+		}
+		// a rendered path like {{.Vars.dir}}/x with dir=.. must not leave the project directory
+		dest = path.Clean(dest)
+		if path.IsAbs(dest) || dest == ".." || strings.HasPrefix(dest, "../") {
+			return fmt.Errorf("%s: the rendered path %s is outside of the project directory", name, dest)
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if !raw {
+			if content, err = Render(name, content, data); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		files = append(files, File{Path: dest, Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(files, func(a, b File) int { return strings.Compare(a.Path, b.Path) })
+	return files, nil
+}
+
+// RenderHooks renders the arguments of the post-init hooks
+func RenderHooks(manifest *Manifest, data Data) ([][]string, error) {
+	commands := make([][]string, 0, len(manifest.Hooks.PostInit))
+	for _, hook := range manifest.Hooks.PostInit {
+		command := make([]string, 0, len(hook))
+		for _, arg := range hook {
+			rendered, err := RenderString(ManifestFile, arg, data)
+			if err != nil {
+				return nil, err
+			}
+			command = append(command, rendered)
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+func validGlob(pattern string) error {
+	for _, elem := range strings.Split(pattern, "/") {
+		if _, err := path.Match(elem, strconst.Empty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchGlob matches a slash separated path against a glob, ** matching any number of
+// directories and the other elements being matched by path.Match
+func matchGlob(pattern, name string) (bool, error) {
+	return matchElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchElems(pattern, name []string) (bool, error) {
+	if len(pattern) == 0 {
+		return len(name) == 0, nil
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if ok, err := matchElems(pattern[1:], name[i:]); ok || err != nil {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	if len(name) == 0 {
+		return false, nil
+	}
+	ok, err := path.Match(pattern[0], name[0])
+	if !ok || err != nil {
+		return false, err
+	}
+	return matchElems(pattern[1:], name[1:])
+}
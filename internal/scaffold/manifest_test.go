@@ -0,0 +1,187 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			manifest: `name: service
+variables:
+  - name: port
+    type: int
+    default: "8080"
+  - name: database
+    type: choice
+    choices: [none, postgres]
+    default: none
+  - name: db_name
+    regex: '[a-z_]+'
+    default: "{{.ProjectName | snake}}"
+    when: ne .Vars.database "none"
+files:
+  - path: assets/**
+    raw: true
+hooks:
+  post_init:
+    - [go, mod, tidy]
+`,
+		},
+		{name: "unknown field", manifest: "name: service\nvariabels: []\n", wantErr: "field variabels not found"},
+		{name: "invalid variable name", manifest: "variables:\n  - name: http-port\n", wantErr: `invalid name "http-port"`},
+		{name: "duplicate variable", manifest: "variables:\n  - name: port\n  - name: port\n", wantErr: `duplicate name "port"`},
+		{name: "reserved variable", manifest: "variables:\n  - name: module_path\n", wantErr: `duplicate name "module_path"`},
+		{name: "invalid type", manifest: "variables:\n  - name: port\n    type: float\n", wantErr: `invalid type "float"`},
+		{name: "choice without choices", manifest: "variables:\n  - name: db\n    type: choice\n", wantErr: "choices must be set"},
+		{name: "regex of an int", manifest: "variables:\n  - name: port\n    type: int\n    regex: '[0-9]+'\n", wantErr: "regex is only supported"},
+		{name: "invalid regex", manifest: "variables:\n  - name: name\n    regex: '[a-z'\n", wantErr: "invalid regex"},
+		{name: "invalid default", manifest: "variables:\n  - name: port\n    type: int\n    default: http\n", wantErr: "invalid default"},
+		{name: "empty file path", manifest: "files:\n  - raw: true\n", wantErr: "files[0]: empty path"},
+		{name: "invalid file path", manifest: "files:\n  - path: docs/[\n", wantErr: "invalid path"},
+		{name: "empty hook", manifest: "hooks:\n  post_init:\n    - []\n", wantErr: "empty command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadManifest(fstest.MapFS{ManifestFile: {Data: []byte(tt.manifest)}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadManifest() failed, err = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadManifest() failed, err = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadManifest(fstest.MapFS{}); err == nil || !strings.Contains(err.Error(), "no manifest") {
+		t.Errorf("LoadManifest() without manifest failed, err = %v", err)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	fsys := fstest.MapFS{
+		ManifestFile:                           {Data: []byte("name: service\n")},
+		".git/HEAD":                            {Data: []byte("ref: refs/heads/main\n")},
+		"README.md.tpl":                        {Data: []byte("# {{.ProjectName}}\n")},
+		"TEMPLATE.md":                          {Data: []byte("how to use the template\n")},
+		"cmd/{{.ProjectName}}/main.go":         {Data: []byte("package main // {{.Vars.port}}\n")},
+		"charts/app/templates/svc.yaml":        {Data: []byte("port: {{ .Values.port }}\n")},
+		"{{if .Vars.docker}}Dockerfile{{end}}": {Data: []byte("FROM scratch\n")},
+		"deploy/prod.yaml":                     {Data: []byte("env: prod\n")},
+	}
+	manifest := &Manifest{
+		Files: []FileRule{
+			{Path: "TEMPLATE.md", Exclude: true},
+			{Path: "charts/**", Raw: true},
+			{Path: "deploy/*.yaml", When: `eq .Vars.env "prod"`},
+		},
+	}
+
+	tests := []struct {
+		name string
+		vars map[string]any
+		want map[string]string
+	}{
+		{
+			name: "all files",
+			vars: map[string]any{"port": "8080", "docker": true, "env": "prod"},
+			want: map[string]string{
+				"Dockerfile":                    "FROM scratch\n",
+				"README.md":                     "# app\n",
+				"charts/app/templates/svc.yaml": "port: {{ .Values.port }}\n",
+				"cmd/app/main.go":               "package main // 8080\n",
+				"deploy/prod.yaml":              "env: prod\n",
+			},
+		},
+		{
+			name: "conditional files left out",
+			vars: map[string]any{"port": "9090", "docker": false, "env": "dev"},
+			want: map[string]string{
+				"README.md":                     "# app\n",
+				"charts/app/templates/svc.yaml": "port: {{ .Values.port }}\n",
+				"cmd/app/main.go":               "package main // 9090\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := RenderTemplate(fsys, manifest, Data{ProjectName: "app", Vars: tt.vars})
+			if err != nil {
+				t.Fatalf("RenderTemplate() failed, err = %v", err)
+			}
+			got := map[string]string{}
+			for _, file := range files {
+				got[file.Path] = string(file.Content)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("RenderTemplate() failed, got %d files %v, want %d", len(got), got, len(tt.want))
+			}
+			for path, content := range tt.want {
+				if got[path] != content {
+					t.Errorf("RenderTemplate() %s = %q, want %q", path, got[path], content)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderTemplateOutsideProject(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		vars map[string]any
+	}{
+		{name: "parent directory variable", file: "{{.Vars.dir}}/escape.txt", vars: map[string]any{"dir": ".."}},
+		{name: "literal parent path", file: `{{"..\x2f..\x2fx"}}`},
+		{name: "absolute path", file: `{{"\x2ftmp\x2fx"}}`},
+		{name: "parent directory itself", file: `a/{{"..\x2f.."}}`},
+	}
+
+	// the slashes of the literal paths are escaped, a template file name has none
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{tt.file: {Data: []byte("escaped\n")}}
+			_, err := RenderTemplate(fsys, &Manifest{}, Data{ProjectName: "app", Vars: tt.vars})
+			if err == nil || !strings.Contains(err.Error(), "outside of the project directory") {
+				t.Errorf("RenderTemplate() failed, err = %v, want the path rejected", err)
+			}
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*.png", name: "logo.png", want: true},
+		{pattern: "*.png", name: "assets/logo.png", want: false},
+		{pattern: "**/*.png", name: "assets/img/logo.png", want: true},
+		{pattern: "**/*.png", name: "logo.png", want: true},
+		{pattern: "assets/**", name: "assets/img/logo.png", want: true},
+		{pattern: "assets/**", name: "docs/logo.png", want: false},
+		{pattern: "a/**/c.go", name: "a/c.go", want: true},
+		{pattern: "a/**/c.go", name: "a/b/b/c.go", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got, err := matchGlob(tt.pattern, tt.name); err != nil || got != tt.want {
+				t.Errorf("matchGlob() failed, got = %v, err = %v, want = %v", got, err, tt.want)
+			}
+		})
+	}
+}
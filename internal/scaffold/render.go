@@ -0,0 +1,110 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Data is what the project templates are rendered with
+type Data struct {
+	// ProjectName is the name of the project directory
+	ProjectName string
+	// ModulePath is the path of the Go module, e.g. github.com/thought2code/godev
+	ModulePath string
+	// GoVersion is the version of the go directive of go.mod, e.g. 1.25.5
+	GoVersion string
+	// Type is the project type, one of the Type constants
+	Type   string
+	Author string
+	Year   int
+	// License is the SPDX identifier of the license, empty without license
+	License string
+	// Features are the optional parts of the project which were selected
+	Features []string
+	// LintProfileMarker and LintProfileLinters are the marker and the linters section of the
+	// lint profile written to .golangci.yml
+	LintProfileMarker  string
+	LintProfileLinters string
+	// Vars are the values of the variables of the manifest of an external template
+	Vars map[string]any
+}
+
+// HasFeature tells whether the feature was selected, for '{{if .HasFeature "docker"}}'
+func (d Data) HasFeature(name string) bool {
+	return slices.Contains(d.Features, name)
+}
+
+// default delimiters of text/template
+const (
+	DefaultLeftDelim  = "{{"
+	DefaultRightDelim = "}}"
+)
+
+// delimsPattern matches the first line of a template changing its delimiters, the directive
+// being in a comment of the language of the file, e.g. "# godev:delims [[ ]]" in a Helm chart
+// or a GitHub Actions workflow using {{ and ${{ themselves
+var delimsPattern = regexp.MustCompile(`godev:delims\s+(\S+)\s+(\S+)`)
+
+// Delims returns the delimiters of the template and its content without the delimiters
+// directive, the default delimiters when it has no directive
+func Delims(content []byte) (left, right string, body []byte) {
+	line, rest, _ := bytes.Cut(content, []byte(strconst.NewLine))
+	match := delimsPattern.FindSubmatch(line)
+	if match == nil {
+		return DefaultLeftDelim, DefaultRightDelim, content
+	}
+	return string(match[1]), string(match[2]), rest
+}
+
+// Render renders the named template with the data, the name being used in the errors
+func Render(name string, content []byte, data any) ([]byte, error) {
+	left, right, body := Delims(content)
+	if left == right {
+		return nil, fmt.Errorf("template %s: the left and right delimiters are both %q", name, left)
+	}
+
+	tmpl, err := template.New(name).Delims(left, right).Funcs(Funcs()).Option("missingkey=error").Parse(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderString renders a template given inline, like a path of an external template
+func RenderString(name, text string, data any) (string, error) {
+	if !strings.Contains(text, DefaultLeftDelim) {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Funcs(Funcs()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return strconst.Empty, fmt.Errorf("failed to parse %q: %w", text, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return strconst.Empty, fmt.Errorf("failed to render %q: %w", text, err)
+	}
+	return b.String(), nil
+}
+
+// Eval evaluates a condition like 'eq .Vars.database "postgres"', true when it is empty
+func Eval(name, condition string, data any) (bool, error) {
+	if strings.TrimSpace(condition) == strconst.Empty {
+		return true, nil
+	}
+	out, err := RenderString(name, DefaultLeftDelim+"if "+condition+DefaultRightDelim+"true"+DefaultLeftDelim+"end"+DefaultRightDelim, data)
+	if err != nil {
+		return false, err
+	}
+	return out == "true", nil
+}
@@ -0,0 +1,103 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDelims(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantLeft  string
+		wantRight string
+		wantBody  string
+	}{
+		{name: "no directive", content: "module {{.ModulePath}}\n", wantLeft: "{{", wantRight: "}}", wantBody: "module {{.ModulePath}}\n"},
+		{name: "yaml comment", content: "# godev:delims [[ ]]\nname: [[.ProjectName]]\n", wantLeft: "[[", wantRight: "]]", wantBody: "name: [[.ProjectName]]\n"},
+		{name: "html comment", content: "<!-- godev:delims <% %> -->\n<%.Author%>", wantLeft: "<%", wantRight: "%>", wantBody: "<%.Author%>"},
+		{name: "directive not on first line", content: "a: 1\n# godev:delims [[ ]]\n", wantLeft: "{{", wantRight: "}}", wantBody: "a: 1\n# godev:delims [[ ]]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right, body := Delims([]byte(tt.content))
+			if left != tt.wantLeft || right != tt.wantRight || string(body) != tt.wantBody {
+				t.Errorf("Delims() failed, got = %q %q %q, want = %q %q %q", left, right, body, tt.wantLeft, tt.wantRight, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := Data{
+		ProjectName: "my-app",
+		ModulePath:  "github.com/acme/my-app",
+		GoVersion:   "1.25.5",
+		Author:      "Jane Doe",
+		Year:        2026,
+		License:     "MIT",
+		Features:    []string{"docker"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "fields",
+			content: "module {{.ModulePath}}\n\ngo {{.GoVersion}}\n",
+			want:    "module github.com/acme/my-app\n\ngo 1.25.5\n",
+		},
+		{
+			name:    "conditionals and functions",
+			content: `{{if .HasFeature "docker"}}FROM golang:{{.GoVersion}}{{end}} {{.ProjectName | snake}} {{camel .ProjectName}} {{upper .License}}`,
+			want:    "FROM golang:1.25.5 my_app myApp MIT",
+		},
+		{
+			name:    "loops",
+			content: `{{range .Features}}- {{.}}{{end}}`,
+			want:    "- docker",
+		},
+		{
+			name:    "custom delimiters keep the default ones",
+			content: "# godev:delims [[ ]]\nimage: ${{ matrix.go }}-[[.GoVersion]]\n",
+			want:    "image: ${{ matrix.go }}-1.25.5\n",
+		},
+		{
+			name:    "unknown field",
+			content: "{{.Unknown}}",
+			wantErr: "can't evaluate field Unknown",
+		},
+		{
+			name:    "unknown function",
+			content: "{{.ProjectName | shout}}",
+			wantErr: `function "shout" not defined`,
+		},
+		{
+			name:    "same delimiters",
+			content: "# godev:delims %% %%\n",
+			wantErr: "delimiters are both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render("test.tpl", []byte(tt.content), data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Render() failed, err = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() failed, err = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Render() failed, got = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package scaffold
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/thought2code/godev/internal/osutil"
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// Source is where an external template comes from, a local directory or a git repository
+type Source struct {
+	// Local is the directory of a local template, empty for a git repository
+	Local string
+	// Repo is the URL of the git repository
+	Repo string
+	// Ref is the branch, tag or commit of the repository, its default branch when empty
+	Ref string
+	// Subdir is the directory of the template in the repository, its root when empty
+	Subdir string
+}
+
+func (s Source) String() string {
+	if s.Local != strconst.Empty {
+		return s.Local
+	}
+	str := s.Repo
+	if s.Subdir != strconst.Empty {
+		str += "//" + s.Subdir
+	}
+	if s.Ref != strconst.Empty {
+		str += "@" + s.Ref
+	}
+	return str
+}
+
+// scpLikePattern matches the git URLs like git@github.com:acme/templates.git
+var scpLikePattern = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// ParseSource parses <path-or-git-url>[//subdir][@ref]. A URL with a scheme, an scp-like URL
+// (git@host:path) or a path ending with .git is a git repository, anything else a local
+// directory taken as is
+func ParseSource(s string) (Source, error) {
+	if s == strconst.Empty {
+		return Source{}, fmt.Errorf("empty template source")
+	}
+
+	scheme := strings.Index(s, "://")
+	// the ref follows the last @ of the path, the user of the URL being before it
+	pathStart := 0
+	switch {
+	case scheme > 0:
+		pathStart = scheme + len("://") + strings.Index(s[scheme+len("://"):], "/")
+	case scpLikePattern.MatchString(s):
+		pathStart = strings.Index(s, ":")
+	}
+	rest, ref := s, strconst.Empty
+	if i := strings.LastIndex(s, "@"); i > pathStart {
+		rest, ref = s[:i], s[i+1:]
+	}
+	isGit := scheme > 0 || scpLikePattern.MatchString(s) ||
+		strings.HasSuffix(strings.TrimSuffix(rest, "/"), ".git") || strings.Contains(rest, ".git//")
+	if !isGit {
+		return Source{Local: s}, nil
+	}
+
+	repo, subdir := rest, strconst.Empty
+	start := 0
+	if scheme > 0 {
+		start = scheme + len("://")
+	}
+	if i := strings.Index(rest[start:], "//"); i >= 0 {
+		repo, subdir = rest[:start+i], strings.Trim(rest[start+i+2:], "/")
+	}
+	if repo == strconst.Empty {
+		return Source{}, fmt.Errorf("invalid template source %q: no repository", s)
+	}
+	if strings.HasSuffix(s, "@") && ref == strconst.Empty {
+		return Source{}, fmt.Errorf("invalid template source %q: empty ref", s)
+	}
+	return Source{Repo: repo, Ref: ref, Subdir: subdir}, nil
+}
+
+// Fetched is a template ready to render
+type Fetched struct {
+	Dir string
+	// Commit is the checked out commit of a git repository
+	Commit string
+	// UpdateErr tells why the cached clone could not be updated, the template being used
+	// offline at the revision of the last update
+	UpdateErr error
+}
+
+// CacheDir returns the directory of the cached clones of the template repositories
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return strconst.Empty, err
+	}
+	return filepath.Join(dir, "godev", "templates"), nil
+}
+
+// Fetch returns the directory of the template. A repository is cloned into the cache directory
+// once, then updated when it is used again, falling back to the cached clone when the update
+// fails, e.g. without network
+func Fetch(ctx context.Context, src Source, cacheDir string) (*Fetched, error) {
+	if src.Local != strconst.Empty {
+		dir, err := filepath.Abs(src.Local)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("template directory %s not found", src.Local)
+		}
+		return &Fetched{Dir: dir}, nil
+	}
+
+	sum := sha256.Sum256([]byte(src.Repo))
+	clone := filepath.Join(cacheDir, hex.EncodeToString(sum[:8]))
+	fetched := &Fetched{}
+
+	exist, err := osutil.CheckExist(filepath.Join(clone, ".git"))
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		if _, err := git(ctx, clone, "fetch", "--tags", "--force", "--prune", "origin"); err != nil {
+			fetched.UpdateErr = err
+		}
+	} else {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return nil, err
+		}
+		if _, err := git(ctx, cacheDir, "clone", "--quiet", src.Repo, clone); err != nil {
+			return nil, fmt.Errorf("failed to clone %s: %w", src.Repo, err)
+		}
+	}
+
+	rev, err := resolveRef(ctx, clone, src.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(ctx, clone, "checkout", "--quiet", "--force", "--detach", rev); err != nil {
+		return nil, err
+	}
+	if fetched.Commit, err = git(ctx, clone, "rev-parse", "--short", "HEAD"); err != nil {
+		return nil, err
+	}
+
+	fetched.Dir = filepath.Join(clone, filepath.FromSlash(src.Subdir))
+	if info, err := os.Stat(fetched.Dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("directory %s not found in %s", src.Subdir, src.Repo)
+	}
+	return fetched, nil
+}
+
+// resolveRef finds the commit of the ref, a branch being taken from the remote to follow
+// its updates
+func resolveRef(ctx context.Context, clone, ref string) (string, error) {
+	candidates := []string{"origin/HEAD"}
+	if ref != strconst.Empty {
+		candidates = []string{"origin/" + ref, ref}
+	}
+	for _, candidate := range candidates {
+		if rev, err := git(ctx, clone, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			return rev, nil
+		}
+	}
+	return strconst.Empty, fmt.Errorf("ref %s not found in the template repository", ref)
+}
+
+// git runs the git command in the directory, without prompting for credentials. It runs in
+// dry-run mode too, the template being needed to plan the files of the project
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	return osutil.CommandOutputContext(ctx, osutil.Command{
+		Name: "git",
+		Args: append([]string{"-C", dir}, args...),
+		Env:  []string{"GIT_TERMINAL_PROMPT=0"},
+	})
+}
@@ -0,0 +1,41 @@
+package scaffold
+
+import "testing"
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    Source
+		wantErr bool
+	}{
+		{name: "local directory", source: "../templates/service", want: Source{Local: "../templates/service"}},
+		{name: "local directory with @", source: "./team@acme/service", want: Source{Local: "./team@acme/service"}},
+		{name: "https", source: "https://github.com/acme/templates", want: Source{Repo: "https://github.com/acme/templates"}},
+		{name: "https with ref", source: "https://github.com/acme/templates@v1.2.0", want: Source{Repo: "https://github.com/acme/templates", Ref: "v1.2.0"}},
+		{name: "branch with slash", source: "https://github.com/acme/templates@feature/grpc", want: Source{Repo: "https://github.com/acme/templates", Ref: "feature/grpc"}},
+		{name: "subdir", source: "https://github.com/acme/templates//go/service@main", want: Source{Repo: "https://github.com/acme/templates", Subdir: "go/service", Ref: "main"}},
+		{name: "user in url", source: "ssh://git@github.com/acme/templates.git", want: Source{Repo: "ssh://git@github.com/acme/templates.git"}},
+		{name: "scp-like", source: "git@github.com:acme/templates.git", want: Source{Repo: "git@github.com:acme/templates.git"}},
+		{name: "scp-like with ref", source: "git@github.com:acme/templates.git@v2", want: Source{Repo: "git@github.com:acme/templates.git", Ref: "v2"}},
+		{name: "local repository", source: "../templates.git@v1", want: Source{Repo: "../templates.git", Ref: "v1"}},
+		{name: "file url with subdir", source: "file:///srv/templates//service", want: Source{Repo: "file:///srv/templates", Subdir: "service"}},
+		{name: "empty ref", source: "https://github.com/acme/templates@", wantErr: true},
+		{name: "empty", source: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSource(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSource() failed, err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSource() failed, got = %+v, want = %+v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.source {
+				t.Errorf("Source.String() failed, got = %v, want = %v", got.String(), tt.source)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// project types of 'godev init --type'
+const (
+	TypeCLI    = "cli"
+	TypeLib    = "lib"
+	TypeHTTP   = "http"
+	TypeGRPC   = "grpc"
+	TypeWorker = "worker"
+)
+
+// DefaultType is the project type created unless another one is chosen
+const DefaultType = TypeCLI
+
+// FeatureDocker adds a Dockerfile building the main package into a distroless image
+const FeatureDocker = "docker"
+
+// ProjectType is an archetype of project, its skeleton being rendered from template/types/<Name>
+// on top of the base templates, then from template/features/<feature> for each of its features
+type ProjectType struct {
+	Name        string
+	Description string
+	Features    []string
+}
+
+var projectTypes = []ProjectType{
+	{Name: TypeCLI, Description: "Command line application with subcommands, flags and version information"},
+	{Name: TypeLib, Description: "Importable library package with an example test, without main package"},
+	{Name: TypeHTTP, Description: "HTTP service with a JSON API, health endpoint and graceful shutdown", Features: []string{FeatureDocker}},
+	{Name: TypeGRPC, Description: "gRPC service with the health and reflection services and graceful shutdown", Features: []string{FeatureDocker}},
+	{Name: TypeWorker, Description: "Background worker processing jobs periodically until it is stopped", Features: []string{FeatureDocker}},
+}
+
+// Types returns the project types of 'godev init'
+func Types() []ProjectType {
+	return projectTypes
+}
+
+// FindType returns the named project type
+func FindType(name string) (ProjectType, error) {
+	names := make([]string, 0, len(projectTypes))
+	for _, t := range projectTypes {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return ProjectType{}, fmt.Errorf("unknown project type %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Dirs returns the template directories of the project type under root, in the order they
+// are rendered, the files of a later directory replacing the ones of an earlier directory
+func (t ProjectType) Dirs(root string) []string {
+	dirs := []string{root + "/base", root + "/types/" + t.Name}
+	for _, feature := range t.Features {
+		dirs = append(dirs, root+"/features/"+feature)
+	}
+	return dirs
+}
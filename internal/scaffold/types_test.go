@@ -0,0 +1,131 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"go/format"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thought2code/godev/internal/osutil"
+)
+
+func TestFindType(t *testing.T) {
+	for _, projectType := range Types() {
+		if got, err := FindType(projectType.Name); err != nil || got.Name != projectType.Name {
+			t.Errorf("FindType(%q) failed, got = %v, err = %v", projectType.Name, got.Name, err)
+		}
+	}
+	if _, err := FindType("desktop"); err == nil || !strings.Contains(err.Error(), "cli, lib, http, grpc, worker") {
+		t.Errorf("FindType() of an unknown type failed, err = %v", err)
+	}
+}
+
+// TestTypeTemplates renders the templates of godev for every project type and checks the Go
+// files are gofmt formatted
+func TestTypeTemplates(t *testing.T) {
+	fsys := os.DirFS("../..")
+
+	for _, projectType := range Types() {
+		t.Run(projectType.Name, func(t *testing.T) {
+			data := typeData(projectType)
+			files, err := RenderDirs(fsys, projectType.Dirs("template"), data)
+			if err != nil {
+				t.Fatalf("RenderDirs() failed, err = %v", err)
+			}
+
+			paths := map[string]bool{}
+			for _, file := range files {
+				paths[file.Path] = true
+				if !strings.HasSuffix(file.Path, ".go") {
+					continue
+				}
+				formatted, err := format.Source(file.Content)
+				if err != nil {
+					t.Errorf("%s is not valid Go: %v", file.Path, err)
+				} else if !bytes.Equal(formatted, file.Content) {
+					t.Errorf("%s is not gofmt formatted:\n%s", file.Path, file.Content)
+				}
+			}
+
+			for _, path := range []string{"go.mod", "godev.yaml", ".golangci.yml"} {
+				if !paths[path] {
+					t.Errorf("RenderDirs() did not render %s", path)
+				}
+			}
+			if got := paths["Dockerfile"]; got != data.HasFeature(FeatureDocker) {
+				t.Errorf("RenderDirs() rendered Dockerfile = %v, want it with the docker feature only", got)
+			}
+		})
+	}
+}
+
+// TestTypeTemplatesVet writes the project of every type to disk and runs 'go vet' on it. The
+// types depending on other modules, like grpc, need network access to download them
+func TestTypeTemplatesVet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go vet of the project types in short mode")
+	}
+	fsys := os.DirFS("../..")
+
+	for _, projectType := range Types() {
+		t.Run(projectType.Name, func(t *testing.T) {
+			if projectType.Name == TypeGRPC && !networkAvailable() {
+				t.Skip("skipping the grpc type, its dependencies can not be downloaded without network access")
+			}
+
+			files, err := RenderDirs(fsys, projectType.Dirs("template"), typeData(projectType))
+			if err != nil {
+				t.Fatalf("RenderDirs() failed, err = %v", err)
+			}
+			// temp dir for test
+			dir := t.TempDir()
+			for _, file := range files {
+				dest := filepath.Join(dir, filepath.FromSlash(file.Path))
+				if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(dest, file.Content, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// go mod tidy adds the requirements of the imports, like 'godev init' does
+			env := []string{"GOTOOLCHAIN=local", "GOFLAGS=-mod=mod"}
+			for _, args := range [][]string{{"mod", "tidy"}, {"vet", "./..."}} {
+				command := osutil.Command{Name: "go", Args: args, Dir: dir, Env: env}
+				if _, err := osutil.CommandOutputContext(context.Background(), command); err != nil {
+					t.Fatalf("go %s failed, err = %v", strings.Join(args, " "), err)
+				}
+			}
+		})
+	}
+}
+
+func typeData(projectType ProjectType) Data {
+	return Data{
+		ProjectName: "my-app",
+		ModulePath:  "github.com/acme/my-app",
+		GoVersion:   "1.25.5",
+		Type:        projectType.Name,
+		Year:        2026,
+		Features:    projectType.Features,
+	}
+}
+
+// networkAvailable tells whether the go command can download modules from its proxy
+func networkAvailable() bool {
+	if os.Getenv("GOPROXY") == "off" {
+		return false
+	}
+	conn, err := net.DialTimeout("tcp", "proxy.golang.org:443", 3*time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
@@ -0,0 +1,264 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+// types of the variables
+const (
+	VarString = "string"
+	VarBool   = "bool"
+	VarInt    = "int"
+	VarChoice = "choice"
+)
+
+var VarTypes = []string{VarString, VarBool, VarInt, VarChoice}
+
+// VarModulePath is the built-in variable of the module path, ModulePath of the data
+const VarModulePath = "module_path"
+
+// Variable is a value asked when the project is created, rendered as {{.Vars.<name>}}
+type Variable struct {
+	Name string `yaml:"name"`
+	// Type is one of VarTypes, string when empty. A bool is rendered as true or false and an
+	// int as a number, while a choice is one of Choices
+	Type    string   `yaml:"type,omitempty"`
+	Choices []string `yaml:"choices,omitempty"`
+	// Prompt is the question asked for the value, the name when empty
+	Prompt string `yaml:"prompt,omitempty"`
+	// Help is printed before the prompt
+	Help string `yaml:"help,omitempty"`
+	// Default is the value of an empty answer, it can use the other fields and the variables
+	// declared before, like {{.ProjectName}}-{{.Vars.component}}
+	Default string `yaml:"default,omitempty"`
+	// Regex must match the whole value of a string variable
+	Regex string `yaml:"regex,omitempty"`
+	// When is a condition like 'eq .Vars.database "postgres"' on the variables declared before,
+	// the variable is only asked when it is true and has the zero value of its type otherwise
+	When string `yaml:"when,omitempty"`
+}
+
+// BuiltinVariables are asked for every template, before the variables of its manifest
+func BuiltinVariables() []Variable {
+	return []Variable{
+		{
+			Name:    VarModulePath,
+			Prompt:  "Git repository",
+			Help:    "Module path of the project, e.g. github.com/thought2code/godev",
+			Default: "{{.ProjectName}}",
+			Regex:   `(https://)?[A-Za-z0-9][A-Za-z0-9._~/-]*`,
+		},
+	}
+}
+
+// variableNamePattern matches the names usable as {{.Vars.<name>}}
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func validateVariables(variables []Variable) error {
+	names := []string{}
+	for _, v := range BuiltinVariables() {
+		names = append(names, v.Name)
+	}
+	for i, v := range variables {
+		if !variableNamePattern.MatchString(v.Name) {
+			return fmt.Errorf("variables[%d]: invalid name %q, expected letters, digits and underscores", i, v.Name)
+		}
+		if slices.Contains(names, v.Name) {
+			return fmt.Errorf("variables[%d]: duplicate name %q", i, v.Name)
+		}
+		names = append(names, v.Name)
+
+		if v.Type != strconst.Empty && !slices.Contains(VarTypes, v.Type) {
+			return fmt.Errorf("variables[%d]: invalid type %q, expected one of %s", i, v.Type, strings.Join(VarTypes, ", "))
+		}
+		if (v.Type == VarChoice) != (len(v.Choices) > 0) {
+			return fmt.Errorf("variables[%d]: choices must be set for the choice type only", i)
+		}
+		if v.Regex != strconst.Empty {
+			if v.Type != strconst.Empty && v.Type != VarString {
+				return fmt.Errorf("variables[%d]: regex is only supported by the string type", i)
+			}
+			if _, err := regexp.Compile(v.Regex); err != nil {
+				return fmt.Errorf("variables[%d]: invalid regex: %w", i, err)
+			}
+		}
+		// a default using the other values is checked once rendered
+		if v.Default != strconst.Empty && !strings.Contains(v.Default, DefaultLeftDelim) {
+			if _, err := v.Parse(v.Default); err != nil {
+				return fmt.Errorf("variables[%d]: invalid default: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Parse converts the input to the type of the variable and validates it
+func (v Variable) Parse(input string) (any, error) {
+	switch v.Type {
+	case VarBool:
+		switch strings.ToLower(input) {
+		case "y", "yes", "true", "1":
+			return true, nil
+		case "n", "no", "false", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a boolean, expected yes or no", input)
+	case VarInt:
+		n, err := strconv.Atoi(input)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", input)
+		}
+		return n, nil
+	case VarChoice:
+		if !slices.Contains(v.Choices, input) {
+			return nil, fmt.Errorf("%q is not one of %s", input, strings.Join(v.Choices, ", "))
+		}
+		return input, nil
+	}
+	if v.Regex != strconst.Empty && !regexp.MustCompile(`^(?:`+v.Regex+`)$`).MatchString(input) {
+		return nil, fmt.Errorf("%q does not match %s", input, v.Regex)
+	}
+	return input, nil
+}
+
+// zero is the value of a variable which was not asked
+func (v Variable) zero() any {
+	switch v.Type {
+	case VarBool:
+		return false
+	case VarInt:
+		return 0
+	}
+	return strconst.Empty
+}
+
+// Asker asks the value of the variable, showing the default of an empty answer and the error
+// of the previous answer when it was invalid
+type Asker func(v Variable, def string, invalid error) (string, error)
+
+// ResolveVariables finds the value of every variable in order: the given value when there is one,
+// else the answer of ask, else the default. A given value which is invalid is an error while an
+// invalid answer is asked again. The values of the variables which are not declared are ignored
+func ResolveVariables(variables []Variable, data Data, values map[string]string, ask Asker) (map[string]any, error) {
+	resolved := make(map[string]any, len(variables))
+	for k, v := range data.Vars {
+		resolved[k] = v
+	}
+
+	for _, v := range variables {
+		data.Vars = resolved
+		visible, err := Eval(v.Name, v.When, data)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: invalid when: %w", v.Name, err)
+		}
+		if !visible {
+			resolved[v.Name] = v.zero()
+			continue
+		}
+
+		def, err := RenderString(v.Name, v.Default, data)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: invalid default: %w", v.Name, err)
+		}
+
+		if input, ok := values[v.Name]; ok {
+			if resolved[v.Name], err = v.Parse(input); err != nil {
+				return nil, fmt.Errorf("variable %s: %w", v.Name, err)
+			}
+			continue
+		}
+
+		var invalid error
+		for {
+			answer := strconst.Empty
+			if ask != nil {
+				if answer, err = ask(v, def, invalid); err != nil {
+					return nil, err
+				}
+			}
+			if answer == strconst.Empty {
+				answer = def
+			}
+			value, err := v.Parse(answer)
+			if err == nil {
+				resolved[v.Name] = value
+				break
+			}
+			// the default is not asked again, nothing would change without input
+			if answer == def {
+				return nil, fmt.Errorf("variable %s: %w", v.Name, err)
+			}
+			invalid = err
+		}
+	}
+	return resolved, nil
+}
+
+// CheckValues returns an error for the values of undeclared variables, likely typos, and for
+// the invalid values, before anything is written
+func CheckValues(values map[string]string, variables []Variable) error {
+	var unknown []string
+	for name, value := range values {
+		i := slices.IndexFunc(variables, func(v Variable) bool { return v.Name == name })
+		if i < 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+		if _, err := variables[i].Parse(value); err != nil {
+			return fmt.Errorf("variable %s: %w", name, err)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+	names := make([]string, 0, len(variables))
+	for _, v := range variables {
+		names = append(names, v.Name)
+	}
+	return fmt.Errorf("unknown variable(s) %s, expected one of %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+}
+
+// ParseValues parses a YAML file of values by variable name, like the ones of --values
+func ParseValues(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch value.(type) {
+		case map[string]any, []any:
+			return nil, fmt.Errorf("value of %s is not a scalar", name)
+		case nil:
+			values[name] = strconst.Empty
+		default:
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// ParseSet parses key=value pairs, like the ones of --set
+func ParseSet(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == strconst.Empty {
+			return nil, fmt.Errorf("invalid value %q, expected key=value", pair)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,181 @@
+package scaffold
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVariableParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		variable Variable
+		input    string
+		want     any
+		wantErr  bool
+	}{
+		{name: "string", variable: Variable{}, input: "api", want: "api"},
+		{name: "regex match", variable: Variable{Regex: `[a-z]+`}, input: "api", want: "api"},
+		{name: "regex partial match", variable: Variable{Regex: `[a-z]+`}, input: "api-v2", wantErr: true},
+		{name: "regex alternation", variable: Variable{Regex: `dev|prod`}, input: "prod", want: "prod"},
+		{name: "bool yes", variable: Variable{Type: VarBool}, input: "Yes", want: true},
+		{name: "bool false", variable: Variable{Type: VarBool}, input: "false", want: false},
+		{name: "bool invalid", variable: Variable{Type: VarBool}, input: "maybe", wantErr: true},
+		{name: "int", variable: Variable{Type: VarInt}, input: "8080", want: 8080},
+		{name: "int invalid", variable: Variable{Type: VarInt}, input: "80a", wantErr: true},
+		{name: "choice", variable: Variable{Type: VarChoice, Choices: []string{"none", "postgres"}}, input: "postgres", want: "postgres"},
+		{name: "choice invalid", variable: Variable{Type: VarChoice, Choices: []string{"none", "postgres"}}, input: "sqlite", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.variable.Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() failed, err = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want && !tt.wantErr {
+				t.Errorf("Parse() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveVariables(t *testing.T) {
+	variables := []Variable{
+		{Name: "port", Type: VarInt, Default: "8080"},
+		{Name: "database", Type: VarChoice, Choices: []string{"none", "postgres"}, Default: "none"},
+		{Name: "db_name", Regex: `[a-z_]+`, Default: "{{.ProjectName | snake}}", When: `ne .Vars.database "none"`},
+		{Name: "metrics", Type: VarBool, Default: "yes"},
+	}
+	data := Data{ProjectName: "my-app"}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		answers []string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "defaults without input",
+			want: map[string]any{"port": 8080, "database": "none", "db_name": "", "metrics": true},
+		},
+		{
+			name:    "answers",
+			answers: []string{"9090", "postgres", "", "no"},
+			want:    map[string]any{"port": 9090, "database": "postgres", "db_name": "my_app", "metrics": false},
+		},
+		{
+			name:    "invalid answers are asked again",
+			answers: []string{"abc", "9090", "sqlite", "postgres", "My-DB", "app_db", "maybe", "yes"},
+			want:    map[string]any{"port": 9090, "database": "postgres", "db_name": "app_db", "metrics": true},
+		},
+		{
+			name:    "given values are not asked",
+			values:  map[string]string{"database": "postgres", "metrics": "false"},
+			answers: []string{"7000", ""},
+			want:    map[string]any{"port": 7000, "database": "postgres", "db_name": "my_app", "metrics": false},
+		},
+		{
+			name:    "invalid given value",
+			values:  map[string]string{"port": "http"},
+			wantErr: `variable port: "http" is not an integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ask Asker
+			if tt.answers != nil {
+				answers := tt.answers
+				ask = func(Variable, string, error) (string, error) {
+					if len(answers) == 0 {
+						return "", errors.New("no more answers")
+					}
+					answer := answers[0]
+					answers = answers[1:]
+					return answer, nil
+				}
+			}
+
+			got, err := ResolveVariables(variables, data, tt.values, ask)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ResolveVariables() failed, err = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVariables() failed, err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveVariables() failed, got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	// an invalid default can not be fixed without input
+	invalid := []Variable{{Name: "name", Regex: `[a-z]+`, Default: "{{.ProjectName}}"}}
+	if _, err := ResolveVariables(invalid, data, nil, nil); err == nil {
+		t.Errorf("ResolveVariables() with an invalid default succeeded, want an error")
+	}
+}
+
+func TestCheckValues(t *testing.T) {
+	variables := []Variable{{Name: "port", Type: VarInt}, {Name: "name"}}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{name: "declared", values: map[string]string{"port": "80", "name": "api"}},
+		{name: "unknown", values: map[string]string{"prot": "80", "nmae": "api"}, wantErr: "unknown variable(s) nmae, prot, expected one of port, name"},
+		{name: "invalid", values: map[string]string{"port": "http"}, wantErr: `variable port: "http" is not an integer`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckValues(tt.values, variables)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("CheckValues() failed, err = %v, want = %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	got, err := ParseValues([]byte("port: 8080\nmetrics: true\nname: api\nempty:\n"))
+	if err != nil {
+		t.Fatalf("ParseValues() failed, err = %v", err)
+	}
+	want := map[string]string{"port": "8080", "metrics": "true", "name": "api", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseValues() failed, got = %v, want = %v", got, want)
+	}
+
+	if got, err := ParseValues(nil); err != nil || len(got) != 0 {
+		t.Errorf("ParseValues() of an empty file failed, got = %v, err = %v", got, err)
+	}
+	if _, err := ParseValues([]byte("db:\n  name: api\n")); err == nil {
+		t.Errorf("ParseValues() of a nested value succeeded, want an error")
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	got, err := ParseSet([]string{"port=8080", "dsn=user=app", "empty="})
+	if err != nil {
+		t.Fatalf("ParseSet() failed, err = %v", err)
+	}
+	want := map[string]string{"port": "8080", "dsn": "user=app", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSet() failed, got = %v, want = %v", got, want)
+	}
+
+	for _, pair := range []string{"port", "=8080"} {
+		if _, err := ParseSet([]string{pair}); err == nil {
+			t.Errorf("ParseSet(%q) succeeded, want an error", pair)
+		}
+	}
+}
@@ -10,6 +10,7 @@ const (
 
 const ProjectVersionTemplateFormat = `
 Version: {{.Name}} {{.Version}} (%s)
+Commit: %s
 Runtime: %s (%s/%s)
 Organization: Thought2Code
 `
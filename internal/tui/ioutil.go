@@ -21,10 +21,12 @@ var (
 	WarnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(AnsiColorBrightYellow)).Render
 )
 
+// shared by all prompts, so buffered input of a piped stdin is not lost between them
+var stdinScanner = bufio.NewScanner(os.Stdin)
+
 func ReadUserInput() (string, error) {
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() {
-		return scanner.Text(), nil
+	if stdinScanner.Scan() {
+		return stdinScanner.Text(), nil
 	}
-	return strconst.Empty, scanner.Err()
+	return strconst.Empty, stdinScanner.Err()
 }
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/thought2code/godev/internal/strconst"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// task states of a Progress
+const (
+	taskPending = iota
+	taskRunning
+	taskSucceeded
+	taskFailed
+)
+
+type progressTask struct {
+	name   string
+	state  int
+	status string
+	start  time.Time
+	took   time.Duration
+}
+
+// Progress shows one status line per task. On a terminal the lines are redrawn in place with a
+// spinner, elsewhere (pipes, CI logs) a line is printed when a task starts and when it finishes
+type Progress struct {
+	mu    sync.Mutex
+	out   io.Writer
+	tty   bool
+	tasks []*progressTask
+	frame int
+	drawn int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewProgress creates the progress of the named tasks written to out
+func NewProgress(out *os.File, names []string) *Progress {
+	tasks := make([]*progressTask, 0, len(names))
+	for _, name := range names {
+		tasks = append(tasks, &progressTask{name: name, status: "waiting"})
+	}
+	return &Progress{
+		out:   out,
+		tty:   isatty.IsTerminal(out.Fd()) || isatty.IsCygwinTerminal(out.Fd()),
+		tasks: tasks,
+	}
+}
+
+// Start draws the tasks and animates the spinner until Stop
+func (p *Progress) Start() {
+	if !p.tty {
+		return
+	}
+
+	p.stop, p.done = make(chan struct{}), make(chan struct{})
+	p.redraw()
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.frame++
+				p.mu.Unlock()
+				p.redraw()
+			}
+		}
+	}()
+}
+
+// Stop draws the final state of the tasks
+func (p *Progress) Stop() {
+	if !p.tty {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.redraw()
+}
+
+// Run marks the task as running with the given status
+func (p *Progress) Run(name, status string) {
+	p.update(name, func(task *progressTask) {
+		task.state, task.status, task.start = taskRunning, status, time.Now()
+	})
+	if !p.tty {
+		p.println(fmt.Sprintf("%s %s %s", strconst.EmojiRunning, name, status))
+	}
+}
+
+// Update replaces the status of a running task, e.g. with its latest output line
+func (p *Progress) Update(name, status string) {
+	p.update(name, func(task *progressTask) { task.status = status })
+}
+
+// Finish marks the task as succeeded or failed according to err
+func (p *Progress) Finish(name string, err error) {
+	var line string
+	p.update(name, func(task *progressTask) {
+		task.took = time.Since(task.start)
+		task.state, task.status = taskSucceeded, "done"
+		if err != nil {
+			task.state, task.status = taskFailed, err.Error()
+		}
+		line = task.line(strconst.Empty)
+	})
+	if !p.tty {
+		p.println(line)
+	}
+}
+
+func (p *Progress) update(name string, apply func(task *progressTask)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, task := range p.tasks {
+		if task.name == name {
+			apply(task)
+		}
+	}
+}
+
+func (p *Progress) println(line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = fmt.Fprintln(p.out, line)
+}
+
+// redraw moves the cursor back to the first task line and rewrites every line
+func (p *Progress) redraw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	if p.drawn > 0 {
+		fmt.Fprintf(&b, "\033[%dA", p.drawn)
+	}
+	spinner := spinnerFrames[p.frame%len(spinnerFrames)]
+	for _, task := range p.tasks {
+		b.WriteString("\r\033[2K")
+		b.WriteString(task.line(spinner))
+		b.WriteString(strconst.NewLine)
+	}
+	p.drawn = len(p.tasks)
+	_, _ = io.WriteString(p.out, b.String())
+}
+
+func (t *progressTask) line(spinner string) string {
+	switch t.state {
+	case taskRunning:
+		return fmt.Sprintf("%s %s %s", spinner, t.name, truncate(t.status, 80))
+	case taskSucceeded:
+		return SuccessStyle(fmt.Sprintf("%s %s (%s)", strconst.EmojiSuccess, t.name, t.took.Round(time.Millisecond)))
+	case taskFailed:
+		return ErrorStyle(fmt.Sprintf("%s %s (%s): %s", strconst.EmojiFailure, t.name, t.took.Round(time.Millisecond), truncate(t.status, 80)))
+	default:
+		return fmt.Sprintf("  %s %s", t.name, t.status)
+	}
+}
+
+// truncate keeps status lines on a single terminal line
+func truncate(s string, width int) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, strconst.NewLine, strconst.Space))
+	if runes := []rune(s); len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,13 +11,26 @@ import (
 	"github.com/thought2code/godev/internal/tui"
 )
 
-//go:embed template/*
+//go:embed all:template
 var embedFS embed.FS
 
+// build metadata, injected at build time via -ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = strconst.ProjectVersion
+	commit  = strconst.Empty
+	date    = strconst.Empty
+)
+
 func main() {
 	cmd.TemplateFS = embedFS
+	cmd.SetBuildInfo(version, commit, date)
 	if err := cmd.Execute(); err != nil {
-		fmt.Println(tui.ErrorStyle(fmt.Sprintf("%s Failed to execute godev: %s", strconst.EmojiFailure, err.Error())))
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle(fmt.Sprintf("%s Failed to execute godev: %s", strconst.EmojiFailure, err.Error())))
+
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -9,3 +9,9 @@ coverage/
 
 # log files
 *.log
+
+# build and release artifacts
+dist/
+
+# project-local tools of godev
+.godev/
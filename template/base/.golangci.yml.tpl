@@ -0,0 +1,17 @@
+{{.LintProfileMarker}}
+version: "2"
+
+formatters:
+  enable:
+    - goimports
+    - gofumpt
+
+  settings:
+    goimports:
+      local-prefixes:
+        - {{.ModulePath}}
+    gofumpt:
+      extra-rules: true
+      module-path: {{.ModulePath}}
+
+{{.LintProfileLinters}}
@@ -0,0 +1,63 @@
+# {{.ProjectName}}
+{{- if eq .Type "cli"}}
+
+Command line application.
+
+```bash
+go run . hello --name gopher
+go run . version
+```
+{{- else if eq .Type "lib"}}
+
+Go library.
+
+```bash
+go get {{.ModulePath}}
+```
+{{- else if eq .Type "http"}}
+
+HTTP service listening on `$ADDR`, `:8080` by default.
+
+```bash
+go run .
+curl localhost:8080/api/v1/hello?name=gopher
+```
+{{- else if eq .Type "grpc"}}
+
+gRPC service listening on `$ADDR`, `:50051` by default, with the health and reflection services.
+
+```bash
+go run .
+grpcurl -plaintext localhost:50051 grpc.health.v1.Health/Check
+```
+{{- else if eq .Type "worker"}}
+
+Background worker running its job every `$INTERVAL`, `30s` by default.
+
+```bash
+INTERVAL=5s go run .
+```
+{{- end}}
+
+## Development
+
+```bash
+godev doctor     # Check the development environment
+godev lint       # Format and lint the code
+godev test unit  # Run the unit tests
+{{- if ne .Type "lib"}}
+godev build      # Build the binaries into dist/
+{{- end}}
+```
+{{- if .HasFeature "docker"}}
+
+```bash
+docker build -t {{.ProjectName}} .
+```
+{{- end}}
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, Copyright (c) {{.Year}} {{.Author}}{{end}}
+{{- end}}
@@ -0,0 +1,37 @@
+# godev configuration, run 'godev config validate' after editing it
+test:
+  coverage_dir: coverage
+
+lint:
+  steps: [goimports, gofumpt, golangci-lint, tidy]
+{{- if ne .Type "lib"}}
+
+build:
+  output: dist
+  trimpath: true
+  version_pkg: main
+{{- if eq .Type "cli"}}
+  platforms: [linux/amd64, linux/arm64, darwin/amd64, darwin/arm64, windows/amd64]
+{{- else}}
+  platforms: [linux/amd64, linux/arm64]
+{{- end}}
+{{- end}}
+{{- if or (.HasFeature "docker") (eq .Type "grpc")}}
+
+doctor:
+  checks:
+{{- if .HasFeature "docker"}}
+    - name: docker
+      type: binary
+      binary: docker
+      severity: warning
+      advice: Install Docker to build the image with 'docker build -t {{.ProjectName}} .'
+{{- end}}
+{{- if eq .Type "grpc"}}
+    - name: protoc
+      type: binary
+      binary: protoc
+      severity: warning
+      advice: Install protoc to generate the code of the services from their .proto files
+{{- end}}
+{{- end}}
@@ -0,0 +1,6 @@
+.git/
+.godev/
+.idea/
+.vscode/
+coverage/
+dist/
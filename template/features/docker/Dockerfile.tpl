@@ -0,0 +1,15 @@
+FROM golang:{{.GoVersion}} AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/{{.ProjectName}} .
+
+FROM gcr.io/distroless/static-debian12:nonroot
+COPY --from=build /out/{{.ProjectName}} /{{.ProjectName}}
+{{- if eq .Type "http"}}
+EXPOSE 8080
+{{- else if eq .Type "grpc"}}
+EXPOSE 50051
+{{- end}}
+ENTRYPOINT ["/{{.ProjectName}}"]
@@ -1,3 +0,0 @@
-module {{.ProjectName}}
-
-go {{.LatestGoVersion}}
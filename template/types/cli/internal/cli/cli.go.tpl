@@ -0,0 +1,83 @@
+// Package cli implements the commands of {{.ProjectName}}.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// BuildInfo describes the binary, printed by the version command
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+type command struct {
+	name  string
+	short string
+	run   func(args []string, build BuildInfo, stdout io.Writer) error
+}
+
+var commands = []command{
+	{name: "hello", short: "Greet someone", run: runHello},
+	{name: "version", short: "Print the version", run: runVersion},
+}
+
+// Run runs the command of the arguments and returns the exit code
+func Run(args []string, build BuildInfo, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return 0
+	}
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		if err := c.run(args[1:], build, stdout); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			fmt.Fprintf(stderr, "{{.ProjectName}} %s: %v\n", c.name, err)
+			return 1
+		}
+		return 0
+	}
+	fmt.Fprintf(stderr, "{{.ProjectName}}: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: {{.ProjectName}} <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.short)
+	}
+}
+
+func runHello(args []string, _ BuildInfo, stdout io.Writer) error {
+	flags := flag.NewFlagSet("hello", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	name := flags.String("name", "world", "name of the person to greet")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	fmt.Fprintf(stdout, "Hello, %s!\n", *name)
+	return nil
+}
+
+func runVersion(_ []string, build BuildInfo, stdout io.Writer) error {
+	fmt.Fprintf(stdout, "{{.ProjectName}} %s", build.Version)
+	if build.Commit != "" {
+		fmt.Fprintf(stdout, " (%s, %s)", build.Commit, build.Date)
+	}
+	fmt.Fprintln(stdout)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	build := BuildInfo{Version: "v1.0.0", Commit: "abc1234", Date: "2025-01-01"}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "usage", args: nil, wantCode: 0, wantStdout: "Usage:"},
+		{name: "hello", args: []string{"hello"}, wantCode: 0, wantStdout: "Hello, world!\n"},
+		{name: "hello with name", args: []string{"hello", "--name", "gopher"}, wantCode: 0, wantStdout: "Hello, gopher!\n"},
+		{name: "hello with arguments", args: []string{"hello", "gopher"}, wantCode: 1, wantStderr: "unexpected arguments"},
+		{name: "version", args: []string{"version"}, wantCode: 0, wantStdout: "v1.0.0 (abc1234, 2025-01-01)"},
+		{name: "unknown command", args: []string{"unknown"}, wantCode: 2, wantStderr: `unknown command "unknown"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, build, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("Run() code = %d, want %d, stderr = %s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("Run() stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Run() stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+
+	"{{.ModulePath}}/internal/cli"
+)
+
+// build metadata, injected by 'godev build'
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+func main() {
+	build := cli.BuildInfo{Version: version, Commit: commit, Date: date}
+	os.Exit(cli.Run(os.Args[1:], build, os.Stdout, os.Stderr))
+}
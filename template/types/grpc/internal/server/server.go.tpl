@@ -0,0 +1,22 @@
+// Package server implements the gRPC server of {{.ProjectName}}.
+package server
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// New returns the gRPC server with the health and reflection services, register the services
+// generated from the .proto files of the project here
+func New(opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	reflection.Register(srv)
+	return srv
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"{{.ModulePath}}/internal/server"
+)
+
+// build metadata, injected by 'godev build'
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	if err := run(logger); err != nil {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger) error {
+	addr := os.Getenv("ADDR")
+	if addr == "" {
+		addr = ":50051"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := server.New()
+
+	errs := make(chan error, 1)
+	go func() {
+		logger.Info("listening", "addr", addr, "version", version, "commit", commit, "date", date)
+		errs <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down")
+	srv.GracefulStop()
+	return <-errs
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "health", method: http.MethodGet, target: "/healthz", wantStatus: http.StatusOK, wantBody: `{"status":"ok"}`},
+		{name: "hello", method: http.MethodGet, target: "/api/v1/hello", wantStatus: http.StatusOK, wantBody: `{"message":"Hello, world!"}`},
+		{name: "hello with name", method: http.MethodGet, target: "/api/v1/hello?name=gopher", wantStatus: http.StatusOK, wantBody: `{"message":"Hello, gopher!"}`},
+		{name: "wrong method", method: http.MethodPost, target: "/api/v1/hello", wantStatus: http.StatusMethodNotAllowed},
+		{name: "not found", method: http.MethodGet, target: "/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
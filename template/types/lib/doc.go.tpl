@@ -0,0 +1,2 @@
+// Package {{.ModulePath | base | pkgname}} greets people.
+package {{.ModulePath | base | pkgname}}
@@ -0,0 +1,12 @@
+package {{.ModulePath | base | pkgname}}_test
+
+import (
+	"fmt"
+
+	"{{.ModulePath}}"
+)
+
+func ExampleGreeting() {
+	fmt.Println({{.ModulePath | base | pkgname}}.Greeting("gopher"))
+	// Output: Hello, gopher!
+}
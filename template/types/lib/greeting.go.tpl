@@ -0,0 +1,15 @@
+package {{.ModulePath | base | pkgname}}
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Greeting returns the greeting of the named person, "world" when the name is blank
+func Greeting(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "world"
+	}
+	return fmt.Sprintf("Hello, %s!", name)
+}
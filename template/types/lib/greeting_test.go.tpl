@@ -0,0 +1,23 @@
+package {{.ModulePath | base | pkgname}}
+
+import "testing"
+
+func TestGreeting(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "name", in: "gopher", want: "Hello, gopher!"},
+		{name: "trimmed name", in: "  gopher ", want: "Hello, gopher!"},
+		{name: "blank name", in: " ", want: "Hello, world!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Greeting(tt.in); got != tt.want {
+				t.Errorf("Greeting(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
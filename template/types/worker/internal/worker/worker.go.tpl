@@ -0,0 +1,29 @@
+// Package worker runs the jobs of {{.ProjectName}}.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is a unit of work, a failed job is logged and retried at the next run
+type Job func(ctx context.Context) error
+
+// Run runs the job right away then every interval until ctx is done, a run in progress
+// finishing before Run returns
+func Run(ctx context.Context, interval time.Duration, job Job, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			logger.Error("job failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
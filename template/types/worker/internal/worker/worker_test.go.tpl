@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "succeeding job"},
+		{name: "failing job", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runs := 0
+			job := func(context.Context) error {
+				// a failed run does not stop the worker
+				if runs++; runs == 3 {
+					cancel()
+				}
+				return tt.err
+			}
+
+			done := make(chan struct{})
+			go func() {
+				Run(ctx, time.Millisecond, job, slog.New(slog.NewTextHandler(io.Discard, nil)))
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Run() did not return after the context was cancelled")
+			}
+			if runs != 3 {
+				t.Errorf("job ran %d times, want 3", runs)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"{{.ModulePath}}/internal/worker"
+)
+
+// build metadata, injected by 'godev build'
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	if err := run(logger); err != nil {
+		logger.Error("worker failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger) error {
+	interval := 30 * time.Second
+	if value := os.Getenv("INTERVAL"); value != "" {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("starting", "interval", interval, "version", version, "commit", commit, "date", date)
+	job := func(context.Context) error {
+		logger.Info("processing")
+		return nil
+	}
+	worker.Run(ctx, interval, job, logger)
+	logger.Info("stopped")
+	return nil
+}